@@ -50,6 +50,8 @@ var applicationEnvCreateCmd = &cobra.Command{
 			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
 		}
 
+		utils.IsSecret = proposeSecret(utils.Key, utils.Value, utils.IsSecret)
+
 		err = utils.CreateServiceVariable(client, projectId, envId, application.Id, utils.ApplicationScope, utils.Key, utils.Value, utils.IsSecret)
 
 		if err != nil {
@@ -25,6 +25,7 @@ var (
 	clusterAnalysisWatch            bool
 	clusterAnalysisNoLogs           bool
 	clusterAnalysisJson             bool
+	clusterAnalysisFailOn           string
 )
 
 var clusterAnalysisCmd = &cobra.Command{
@@ -116,3 +117,18 @@ func analysisReportFromLogs(logs []qovery.ClusterAnalysisLogResponse) string {
 	}
 	return strings.Join(lines, "\n")
 }
+
+// getAnalysisReport fetches an analysis and its logs and builds its structured report.
+func getAnalysisReport(client *qovery.APIClient, clusterId string, analysisId string) (*analysisReport, error) {
+	analysis, res, err := client.ClustersAPI.GetClusterAnalysis(context.Background(), clusterId, analysisId).Execute()
+	if err != nil {
+		return nil, httpError(res, err)
+	}
+
+	logs, res, err := client.ClustersAPI.ListClusterAnalysisLogs(context.Background(), clusterId, analysisId).Execute()
+	if err != nil {
+		return nil, httpError(res, err)
+	}
+
+	return newAnalysisReport(*analysis, logs.GetResults()), nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-client-go"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var clusterAnalysisCompareOutputFormat string
+
+var clusterAnalysisCompareCmd = &cobra.Command{
+	Use:   "compare <analysis_id> <analysis_id>",
+	Short: "Compare two analysis reports of the same kind (new/removed deprecated APIs, cost deltas)",
+	Example: `  qovery cluster analysis compare -c <cluster_id> <old_analysis_id> <new_analysis_id>
+  qovery cluster analysis compare -c <cluster_id> <old_analysis_id> <new_analysis_id> --output markdown`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+
+		from, err := getAnalysisReport(client, clusterAnalysisClusterId, args[0])
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		to, err := getAnalysisReport(client, clusterAnalysisClusterId, args[1])
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if from.Kind != to.Kind {
+			utils.PrintlnError(fmt.Errorf("cannot compare a %s analysis with a %s analysis", from.Kind, to.Kind))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		for _, report := range []*analysisReport{from, to} {
+			if report.ParseError != "" {
+				utils.PrintlnError(fmt.Errorf("cannot compare analysis %s: %s", report.Id, report.ParseError))
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+		}
+
+		comparison := compareAnalysisReports(from, to)
+
+		switch clusterAnalysisCompareOutputFormat {
+		case "json":
+			j, err := json.Marshal(comparison)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			utils.Println(string(j))
+		case "markdown":
+			utils.Println(getAnalysisComparisonMarkdownOutput(comparison, from.Kind))
+		case "table":
+			printAnalysisComparison(comparison, from.Kind)
+		default:
+			utils.PrintlnError(fmt.Errorf("invalid output format %q (allowed: table, json, markdown)", clusterAnalysisCompareOutputFormat))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	},
+}
+
+func printAnalysisComparison(comparison analysisComparison, kind string) {
+	utils.Println(fmt.Sprintf("Comparing analysis %s with analysis %s\n", comparison.From, comparison.To))
+
+	var err error
+	if kind == string(qovery.CLUSTERANALYSISKIND_COST_RECOMMENDATION) {
+		err = utils.PrintTable(costSummaryHeaders, costSummaryRows(comparison))
+	} else {
+		utils.Println(fmt.Sprintf("New deprecated APIs: %d", len(comparison.NewDeprecatedApis)))
+		if len(comparison.NewDeprecatedApis) > 0 {
+			err = utils.PrintTable(deprecatedApiHeaders, deprecatedApiRows(comparison.NewDeprecatedApis))
+		}
+		utils.Println(fmt.Sprintf("\nRemoved deprecated APIs: %d", len(comparison.RemovedDeprecatedApis)))
+		if err == nil && len(comparison.RemovedDeprecatedApis) > 0 {
+			err = utils.PrintTable(deprecatedApiHeaders, deprecatedApiRows(comparison.RemovedDeprecatedApis))
+		}
+	}
+
+	if err != nil {
+		utils.PrintlnError(err)
+		os.Exit(1)
+		panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+	}
+}
+
+func getAnalysisComparisonMarkdownOutput(comparison analysisComparison, kind string) string {
+	output := fmt.Sprintf("# Cluster analysis comparison\n\nFrom `%s` to `%s`\n", comparison.From, comparison.To)
+
+	if kind == string(qovery.CLUSTERANALYSISKIND_COST_RECOMMENDATION) {
+		return output + "\n## Cost\n\n" + markdownTable(costSummaryHeaders, costSummaryRows(comparison))
+	}
+
+	output += fmt.Sprintf("\n## New deprecated APIs (%d)\n\n", len(comparison.NewDeprecatedApis))
+	if len(comparison.NewDeprecatedApis) > 0 {
+		output += markdownTable(deprecatedApiHeaders, deprecatedApiRows(comparison.NewDeprecatedApis))
+	}
+	output += fmt.Sprintf("\n## Removed deprecated APIs (%d)\n\n", len(comparison.RemovedDeprecatedApis))
+	if len(comparison.RemovedDeprecatedApis) > 0 {
+		output += markdownTable(deprecatedApiHeaders, deprecatedApiRows(comparison.RemovedDeprecatedApis))
+	}
+
+	return output
+}
+
+func init() {
+	clusterAnalysisCmd.AddCommand(clusterAnalysisCompareCmd)
+	clusterAnalysisCompareCmd.Flags().StringVarP(&clusterAnalysisClusterId, "cluster-id", "c", "", "Cluster ID")
+	clusterAnalysisCompareCmd.Flags().StringVar(&clusterAnalysisCompareOutputFormat, "output", "table", "Comparison output: table, json, markdown")
+	_ = clusterAnalysisCompareCmd.MarkFlagRequired("cluster-id")
+}
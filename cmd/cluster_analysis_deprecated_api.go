@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -13,6 +14,8 @@ import (
 var clusterAnalysisDeprecatedApiCmd = &cobra.Command{
 	Use:   "deprecated-api",
 	Short: "Start a deprecated Kubernetes API analysis, optionally wait for completion, then print its report",
+	Example: `  # block a CI pipeline when the cluster still uses APIs removed in the next Kubernetes version
+  qovery cluster analysis deprecated-api -c <cluster_id> --target-kubernetes-version 1.33 --fail-on deprecated`,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Capture(cmd)
 
@@ -33,6 +36,15 @@ func newDeprecatedApiRequest() (*qovery.ClusterAnalysisRequest, error) {
 		return nil, err
 	}
 
+	if clusterAnalysisFailOn != "" {
+		if clusterAnalysisFailOn != "deprecated" {
+			return nil, fmt.Errorf("invalid value for --fail-on: %s (allowed: deprecated)", clusterAnalysisFailOn)
+		}
+		if outputFormat != qovery.CLUSTERANALYSISOUTPUTFORMAT_JSON || !clusterAnalysisWatch {
+			return nil, fmt.Errorf("--fail-on requires --output json and --watch")
+		}
+	}
+
 	request := qovery.NewClusterAnalysisRequest(qovery.CLUSTERANALYSISKIND_DEPRECATED_API_CHECK, outputFormat)
 	if clusterAnalysisTargetK8sVersion != "" {
 		request.SetTargetKubernetesVersion(clusterAnalysisTargetK8sVersion)
@@ -46,4 +58,5 @@ func init() {
 
 	addClusterAnalysisRunFlags(clusterAnalysisDeprecatedApiCmd)
 	clusterAnalysisDeprecatedApiCmd.Flags().StringVar(&clusterAnalysisTargetK8sVersion, "target-kubernetes-version", "", "Optional target Kubernetes version")
+	clusterAnalysisDeprecatedApiCmd.Flags().StringVar(&clusterAnalysisFailOn, "fail-on", "", "Exit with a non-zero code when the report contains findings: deprecated")
 }
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var clusterAnalysisExportFile string
+var clusterAnalysisExportOutputFormat string
+
+var clusterAnalysisExportCmd = &cobra.Command{
+	Use:     "export",
+	Short:   "Export the report of a past cluster analysis as json, markdown or html",
+	Example: `  qovery cluster analysis export -c <cluster_id> -a <analysis_id> --output html --file report.html`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+
+		report, err := getAnalysisReport(client, clusterAnalysisClusterId, clusterAnalysisId)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		var output string
+		switch clusterAnalysisExportOutputFormat {
+		case "json":
+			j, jsonErr := json.Marshal(report)
+			output, err = string(j), jsonErr
+		case "markdown":
+			output = getAnalysisReportMarkdownOutput(report)
+		case "html":
+			output, err = getAnalysisReportHtmlOutput(report)
+		default:
+			err = fmt.Errorf("invalid output format %q (allowed: json, markdown, html)", clusterAnalysisExportOutputFormat)
+		}
+
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if clusterAnalysisExportFile == "" {
+			utils.Println(output)
+			return
+		}
+
+		if err := os.WriteFile(clusterAnalysisExportFile, []byte(output), 0644); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		utils.Println(fmt.Sprintf("Analysis %s exported to %s", clusterAnalysisId, clusterAnalysisExportFile))
+	},
+}
+
+func init() {
+	clusterAnalysisCmd.AddCommand(clusterAnalysisExportCmd)
+	clusterAnalysisExportCmd.Flags().StringVarP(&clusterAnalysisClusterId, "cluster-id", "c", "", "Cluster ID")
+	clusterAnalysisExportCmd.Flags().StringVarP(&clusterAnalysisId, "analysis-id", "a", "", "Analysis ID")
+	clusterAnalysisExportCmd.Flags().StringVar(&clusterAnalysisExportOutputFormat, "output", "json", "Export format: json, markdown, html")
+	clusterAnalysisExportCmd.Flags().StringVar(&clusterAnalysisExportFile, "file", "", "Write the export to this file instead of stdout")
+	_ = clusterAnalysisExportCmd.MarkFlagRequired("cluster-id")
+	_ = clusterAnalysisExportCmd.MarkFlagRequired("analysis-id")
+}
@@ -0,0 +1,462 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/qovery/qovery-client-go"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+// deprecatedApiFinding is a resource still using a deprecated Kubernetes API, as reported by a deprecated-api analysis.
+type deprecatedApiFinding struct {
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	ApiVersion  string `json:"api_version"`
+	ReplaceWith string `json:"replace_with"`
+	RemovedIn   string `json:"removed_in"`
+}
+
+func (f deprecatedApiFinding) key() string {
+	return fmt.Sprintf("%s/%s/%s@%s", f.Kind, f.Namespace, f.Name, f.ApiVersion)
+}
+
+// costRecommendation is a container resource recommendation, as reported by a cost-recommendation analysis.
+// CPU values are expressed in cores and memory values in bytes.
+type costRecommendation struct {
+	Namespace         string  `json:"namespace"`
+	Kind              string  `json:"kind"`
+	Name              string  `json:"name"`
+	Container         string  `json:"container"`
+	CurrentCpu        float64 `json:"current_cpu_request"`
+	RecommendedCpu    float64 `json:"recommended_cpu_request"`
+	CurrentMemory     float64 `json:"current_memory_request"`
+	RecommendedMemory float64 `json:"recommended_memory_request"`
+}
+
+func (r costRecommendation) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s", r.Namespace, r.Kind, r.Name, r.Container)
+}
+
+// analysisReport is the structured view of the report produced by a cluster analysis.
+type analysisReport struct {
+	Id                  string                 `json:"id"`
+	Kind                string                 `json:"kind"`
+	Status              string                 `json:"status"`
+	CreatedAt           *string                `json:"created_at"`
+	DeprecatedApis      []deprecatedApiFinding `json:"deprecated_apis,omitempty"`
+	CostRecommendations []costRecommendation   `json:"cost_recommendations,omitempty"`
+	ParseError          string                 `json:"parse_error,omitempty"`
+	Report              string                 `json:"report"`
+}
+
+// costSummary sums the resource requests of all the recommendations of a report.
+type costSummary struct {
+	CurrentCpu        float64 `json:"current_cpu_request"`
+	RecommendedCpu    float64 `json:"recommended_cpu_request"`
+	CurrentMemory     float64 `json:"current_memory_request"`
+	RecommendedMemory float64 `json:"recommended_memory_request"`
+}
+
+// analysisComparison is the difference between two analysis reports.
+type analysisComparison struct {
+	From                  string                 `json:"from"`
+	To                    string                 `json:"to"`
+	NewDeprecatedApis     []deprecatedApiFinding `json:"new_deprecated_apis"`
+	RemovedDeprecatedApis []deprecatedApiFinding `json:"removed_deprecated_apis"`
+	FromCost              costSummary            `json:"from_cost"`
+	ToCost                costSummary            `json:"to_cost"`
+}
+
+// newAnalysisReport builds an analysisReport from an analysis and its log lines.
+// Deprecated APIs and cost recommendations can only be extracted from reports generated with the json output,
+// ParseError is set when they cannot be.
+func newAnalysisReport(analysis qovery.ClusterAnalysisResponse, logs []qovery.ClusterAnalysisLogResponse) *analysisReport {
+	createdAt := analysis.GetCreatedAt()
+	report := &analysisReport{
+		Id:        analysis.GetId(),
+		Kind:      string(analysis.GetKind()),
+		Status:    string(analysis.GetStatus()),
+		CreatedAt: utils.ToIso8601(&createdAt),
+		Report:    analysisReportFromLogs(logs),
+	}
+
+	var err error
+	switch analysis.GetKind() {
+	case qovery.CLUSTERANALYSISKIND_DEPRECATED_API_CHECK:
+		report.DeprecatedApis, err = parseDeprecatedApiFindings(report.Report)
+	case qovery.CLUSTERANALYSISKIND_COST_RECOMMENDATION:
+		report.CostRecommendations, err = parseCostRecommendations(report.Report)
+	}
+	if err != nil {
+		report.ParseError = err.Error()
+	}
+
+	return report
+}
+
+// parseReportObjects decodes a json report and returns its entries, whether the report is a list of
+// objects or an object wrapping them (e.g. {"items": [...]}). It returns an error when the report has
+// another shape, so that an unrecognised report is never mistaken for an empty one.
+func parseReportObjects(report string, wrappers ...string) ([]map[string]interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(report)), &decoded); err != nil {
+		return nil, fmt.Errorf("the report is not valid json (was the analysis run with the json output?): %w", err)
+	}
+
+	var entries []interface{}
+	switch v := decoded.(type) {
+	case []interface{}:
+		entries = v
+	case map[string]interface{}:
+		found := false
+		for _, wrapper := range wrappers {
+			items, present := v[wrapper]
+			if !present {
+				continue
+			}
+			if items == nil {
+				found = true
+				break
+			}
+			if list, ok := items.([]interface{}); ok {
+				entries = list
+				found = true
+				break
+			}
+			return nil, fmt.Errorf("unrecognised report: %q is not a list", wrapper)
+		}
+		if !found {
+			return nil, fmt.Errorf("unrecognised report: expected a list or an object with one of %s", strings.Join(wrappers, ", "))
+		}
+	default:
+		return nil, fmt.Errorf("unrecognised report: expected a list or an object with one of %s", strings.Join(wrappers, ", "))
+	}
+
+	var objects []map[string]interface{}
+	for i, entry := range entries {
+		object, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unrecognised report: entry %d is not an object", i)
+		}
+		objects = append(objects, lowerKeys(object))
+	}
+
+	return objects, nil
+}
+
+func lowerKeys(object map[string]interface{}) map[string]interface{} {
+	lowered := make(map[string]interface{}, len(object))
+	for k, v := range object {
+		if nested, ok := v.(map[string]interface{}); ok {
+			v = lowerKeys(nested)
+		}
+		lowered[strings.ToLower(k)] = v
+	}
+	return lowered
+}
+
+func stringField(object map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := object[key]; ok && v != nil {
+			return fmt.Sprintf("%v", v)
+		}
+	}
+	return ""
+}
+
+func numberField(object map[string]interface{}, keys ...string) float64 {
+	for _, key := range keys {
+		switch v := object[key].(type) {
+		case float64:
+			return v
+		case map[string]interface{}:
+			// recommendations may be wrapped with their severity, e.g. {"value": 0.1, "severity": "OK"}
+			if value, ok := v["value"].(float64); ok {
+				return value
+			}
+		}
+	}
+	return 0
+}
+
+func objectField(object map[string]interface{}, keys ...string) map[string]interface{} {
+	current := object
+	for _, key := range keys {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return map[string]interface{}{}
+		}
+		current = next
+	}
+	return current
+}
+
+func parseDeprecatedApiFindings(report string) ([]deprecatedApiFinding, error) {
+	objects, err := parseReportObjects(report, "items", "results", "findings")
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []deprecatedApiFinding
+	for i, object := range objects {
+		api := objectField(object, "api")
+		finding := deprecatedApiFinding{
+			Kind:        stringField(object, "kind"),
+			Namespace:   stringField(object, "namespace"),
+			Name:        stringField(object, "name"),
+			ApiVersion:  stringField(object, "apiversion", "api_version"),
+			ReplaceWith: stringField(object, "replacewith", "replace_with"),
+			RemovedIn:   stringField(object, "removedin", "removed_in", "since"),
+		}
+		if finding.Kind == "" {
+			finding.Kind = stringField(api, "kind")
+		}
+		if finding.ApiVersion == "" {
+			finding.ApiVersion = stringField(api, "version")
+		}
+		if finding.ReplaceWith == "" {
+			finding.ReplaceWith = stringField(api, "replacement-api")
+		}
+		if finding.RemovedIn == "" {
+			finding.RemovedIn = stringField(api, "removed-in")
+		}
+
+		if finding.Kind == "" && finding.Name == "" {
+			return nil, fmt.Errorf("unrecognised report: entry %d has neither a kind nor a name", i)
+		}
+		findings = append(findings, finding)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].key() < findings[j].key()
+	})
+
+	return findings, nil
+}
+
+func parseCostRecommendations(report string) ([]costRecommendation, error) {
+	objects, err := parseReportObjects(report, "scans", "results")
+	if err != nil {
+		return nil, err
+	}
+
+	var recommendations []costRecommendation
+	for _, object := range objects {
+		target := objectField(object, "object")
+		currentRequests := objectField(target, "allocations", "requests")
+		recommendedRequests := objectField(object, "recommended", "requests")
+
+		recommendations = append(recommendations, costRecommendation{
+			Namespace:         stringField(target, "namespace"),
+			Kind:              stringField(target, "kind"),
+			Name:              stringField(target, "name"),
+			Container:         stringField(target, "container"),
+			CurrentCpu:        numberField(currentRequests, "cpu"),
+			RecommendedCpu:    numberField(recommendedRequests, "cpu"),
+			CurrentMemory:     numberField(currentRequests, "memory"),
+			RecommendedMemory: numberField(recommendedRequests, "memory"),
+		})
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].key() < recommendations[j].key()
+	})
+
+	return recommendations, nil
+}
+
+func getCostSummary(recommendations []costRecommendation) costSummary {
+	var summary costSummary
+	for _, r := range recommendations {
+		summary.CurrentCpu += r.CurrentCpu
+		summary.RecommendedCpu += r.RecommendedCpu
+		summary.CurrentMemory += r.CurrentMemory
+		summary.RecommendedMemory += r.RecommendedMemory
+	}
+	return summary
+}
+
+func compareAnalysisReports(from *analysisReport, to *analysisReport) analysisComparison {
+	comparison := analysisComparison{
+		From:     from.Id,
+		To:       to.Id,
+		FromCost: getCostSummary(from.CostRecommendations),
+		ToCost:   getCostSummary(to.CostRecommendations),
+	}
+
+	fromFindings := make(map[string]bool)
+	for _, f := range from.DeprecatedApis {
+		fromFindings[f.key()] = true
+	}
+	toFindings := make(map[string]bool)
+	for _, f := range to.DeprecatedApis {
+		toFindings[f.key()] = true
+		if !fromFindings[f.key()] {
+			comparison.NewDeprecatedApis = append(comparison.NewDeprecatedApis, f)
+		}
+	}
+	for _, f := range from.DeprecatedApis {
+		if !toFindings[f.key()] {
+			comparison.RemovedDeprecatedApis = append(comparison.RemovedDeprecatedApis, f)
+		}
+	}
+
+	return comparison
+}
+
+func formatCpu(cores float64) string {
+	return fmt.Sprintf("%.0fm", cores*1000)
+}
+
+func formatMemory(bytes float64) string {
+	return fmt.Sprintf("%.0fMi", bytes/(1024*1024))
+}
+
+func formatCpuDelta(cores float64) string {
+	return fmt.Sprintf("%+.0fm", cores*1000)
+}
+
+func formatMemoryDelta(bytes float64) string {
+	return fmt.Sprintf("%+.0fMi", bytes/(1024*1024))
+}
+
+func deprecatedApiRows(findings []deprecatedApiFinding) [][]string {
+	var rows [][]string
+	for _, f := range findings {
+		rows = append(rows, []string{f.Kind, f.Namespace, f.Name, f.ApiVersion, f.ReplaceWith, f.RemovedIn})
+	}
+	return rows
+}
+
+func costRecommendationRows(recommendations []costRecommendation) [][]string {
+	var rows [][]string
+	for _, r := range recommendations {
+		rows = append(rows, []string{
+			r.Namespace,
+			r.Kind + "/" + r.Name,
+			r.Container,
+			formatCpu(r.CurrentCpu),
+			formatCpu(r.RecommendedCpu),
+			formatMemory(r.CurrentMemory),
+			formatMemory(r.RecommendedMemory),
+		})
+	}
+	return rows
+}
+
+func costSummaryRows(comparison analysisComparison) [][]string {
+	return [][]string{
+		{"CPU requests (current)", formatCpu(comparison.FromCost.CurrentCpu), formatCpu(comparison.ToCost.CurrentCpu), formatCpuDelta(comparison.ToCost.CurrentCpu - comparison.FromCost.CurrentCpu)},
+		{"CPU requests (recommended)", formatCpu(comparison.FromCost.RecommendedCpu), formatCpu(comparison.ToCost.RecommendedCpu), formatCpuDelta(comparison.ToCost.RecommendedCpu - comparison.FromCost.RecommendedCpu)},
+		{"Memory requests (current)", formatMemory(comparison.FromCost.CurrentMemory), formatMemory(comparison.ToCost.CurrentMemory), formatMemoryDelta(comparison.ToCost.CurrentMemory - comparison.FromCost.CurrentMemory)},
+		{"Memory requests (recommended)", formatMemory(comparison.FromCost.RecommendedMemory), formatMemory(comparison.ToCost.RecommendedMemory), formatMemoryDelta(comparison.ToCost.RecommendedMemory - comparison.FromCost.RecommendedMemory)},
+	}
+}
+
+var deprecatedApiHeaders = []string{"Kind", "Namespace", "Name", "Api Version", "Replace With", "Removed In"}
+var costRecommendationHeaders = []string{"Namespace", "Workload", "Container", "CPU Request", "Recommended CPU", "Memory Request", "Recommended Memory"}
+var costSummaryHeaders = []string{"Resource", "From", "To", "Delta"}
+
+func markdownTable(headers []string, rows [][]string) string {
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(headers)) + "\n")
+	for _, row := range rows {
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	return b.String()
+}
+
+func getAnalysisReportMarkdownOutput(report *analysisReport) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# Cluster analysis %s\n\n", report.Id))
+	b.WriteString(fmt.Sprintf("- Kind: %s\n- Status: %s\n", report.Kind, report.Status))
+	if report.CreatedAt != nil {
+		b.WriteString(fmt.Sprintf("- Created at: %s\n", *report.CreatedAt))
+	}
+
+	if len(report.DeprecatedApis) > 0 {
+		b.WriteString(fmt.Sprintf("\n## Deprecated APIs (%d)\n\n", len(report.DeprecatedApis)))
+		b.WriteString(markdownTable(deprecatedApiHeaders, deprecatedApiRows(report.DeprecatedApis)))
+	}
+
+	if len(report.CostRecommendations) > 0 {
+		b.WriteString(fmt.Sprintf("\n## Cost recommendations (%d)\n\n", len(report.CostRecommendations)))
+		b.WriteString(markdownTable(costRecommendationHeaders, costRecommendationRows(report.CostRecommendations)))
+	}
+
+	if len(report.DeprecatedApis) == 0 && len(report.CostRecommendations) == 0 {
+		b.WriteString("\n## Report\n\n```\n" + report.Report + "\n```\n")
+	}
+
+	return b.String()
+}
+
+var analysisReportHtmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Cluster analysis {{ .Report.Id }}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>Cluster analysis {{ .Report.Id }}</h1>
+<ul>
+<li>Kind: {{ .Report.Kind }}</li>
+<li>Status: {{ .Report.Status }}</li>
+{{ with .Report.CreatedAt }}<li>Created at: {{ . }}</li>{{ end }}
+<li>Exported at: {{ .ExportedAt }}</li>
+</ul>
+{{ range .Sections }}
+<h2>{{ .Title }}</h2>
+<table>
+<tr>{{ range .Headers }}<th>{{ . }}</th>{{ end }}</tr>
+{{ range .Rows }}<tr>{{ range . }}<td>{{ . }}</td>{{ end }}</tr>
+{{ end }}</table>
+{{ else }}
+<h2>Report</h2>
+<pre>{{ .Report.Report }}</pre>
+{{ end }}
+</body>
+</html>
+`))
+
+func getAnalysisReportHtmlOutput(report *analysisReport) (string, error) {
+	type section struct {
+		Title   string
+		Headers []string
+		Rows    [][]string
+	}
+
+	var sections []section
+	if len(report.DeprecatedApis) > 0 {
+		sections = append(sections, section{fmt.Sprintf("Deprecated APIs (%d)", len(report.DeprecatedApis)), deprecatedApiHeaders, deprecatedApiRows(report.DeprecatedApis)})
+	}
+	if len(report.CostRecommendations) > 0 {
+		sections = append(sections, section{fmt.Sprintf("Cost recommendations (%d)", len(report.CostRecommendations)), costRecommendationHeaders, costRecommendationRows(report.CostRecommendations)})
+	}
+
+	var b bytes.Buffer
+	err := analysisReportHtmlTemplate.Execute(&b, map[string]interface{}{
+		"Report":     report,
+		"Sections":   sections,
+		"ExportedAt": time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestParseDeprecatedApiFindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		report   string
+		expected []deprecatedApiFinding
+		wantErr  bool
+	}{
+		{
+			name:   "list of findings",
+			report: `[{"Name":"web","Namespace":"default","Kind":"Ingress","ApiVersion":"extensions/v1beta1","ReplaceWith":"networking.k8s.io/v1","Since":"1.14.0"}]`,
+			expected: []deprecatedApiFinding{
+				{Kind: "Ingress", Namespace: "default", Name: "web", ApiVersion: "extensions/v1beta1", ReplaceWith: "networking.k8s.io/v1", RemovedIn: "1.14.0"},
+			},
+		},
+		{
+			name:   "wrapped findings with nested api",
+			report: `{"items":[{"name":"cron","namespace":"jobs","api":{"version":"batch/v1beta1","kind":"CronJob","removed-in":"v1.25.0","replacement-api":"batch/v1"}}]}`,
+			expected: []deprecatedApiFinding{
+				{Kind: "CronJob", Namespace: "jobs", Name: "cron", ApiVersion: "batch/v1beta1", ReplaceWith: "batch/v1", RemovedIn: "v1.25.0"},
+			},
+		},
+		{
+			name:     "no findings",
+			report:   `[]`,
+			expected: nil,
+		},
+		{
+			name:     "empty wrapper",
+			report:   `{"items":null}`,
+			expected: nil,
+		},
+		{
+			name:    "not a json report",
+			report:  "NAME  NAMESPACE  KIND",
+			wantErr: true,
+		},
+		{
+			name:    "unknown wrapper",
+			report:  `{"deprecations":[{"name":"web","kind":"Ingress"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unrecognised entries",
+			report:  `[{"resource":"web"}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDeprecatedApiFindings(tt.report)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %d findings, got %d", len(tt.expected), len(got))
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("expected %+v, got %+v", tt.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseCostRecommendations(t *testing.T) {
+	report := `{"scans":[{"object":{"namespace":"default","name":"api","kind":"Deployment","container":"app","allocations":{"requests":{"cpu":0.5,"memory":536870912}}},"recommended":{"requests":{"cpu":{"value":0.1,"severity":"WARNING"},"memory":{"value":268435456,"severity":"OK"}}}}]}`
+
+	got, err := parseCostRecommendations(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 recommendation, got %d", len(got))
+	}
+
+	expected := costRecommendation{
+		Namespace:         "default",
+		Kind:              "Deployment",
+		Name:              "api",
+		Container:         "app",
+		CurrentCpu:        0.5,
+		RecommendedCpu:    0.1,
+		CurrentMemory:     536870912,
+		RecommendedMemory: 268435456,
+	}
+	if got[0] != expected {
+		t.Fatalf("expected %+v, got %+v", expected, got[0])
+	}
+}
+
+func TestCompareAnalysisReports(t *testing.T) {
+	ingress := deprecatedApiFinding{Kind: "Ingress", Namespace: "default", Name: "web", ApiVersion: "extensions/v1beta1"}
+	cronJob := deprecatedApiFinding{Kind: "CronJob", Namespace: "jobs", Name: "cron", ApiVersion: "batch/v1beta1"}
+	psp := deprecatedApiFinding{Kind: "PodSecurityPolicy", Name: "restricted", ApiVersion: "policy/v1beta1"}
+
+	from := &analysisReport{
+		Id:                  "from",
+		DeprecatedApis:      []deprecatedApiFinding{ingress, cronJob},
+		CostRecommendations: []costRecommendation{{CurrentCpu: 1, RecommendedCpu: 0.5}},
+	}
+	to := &analysisReport{
+		Id:                  "to",
+		DeprecatedApis:      []deprecatedApiFinding{cronJob, psp},
+		CostRecommendations: []costRecommendation{{CurrentCpu: 0.5, RecommendedCpu: 0.5}, {CurrentCpu: 0.25, RecommendedCpu: 0.1}},
+	}
+
+	comparison := compareAnalysisReports(from, to)
+
+	if len(comparison.NewDeprecatedApis) != 1 || comparison.NewDeprecatedApis[0] != psp {
+		t.Fatalf("expected %+v to be new, got %+v", psp, comparison.NewDeprecatedApis)
+	}
+	if len(comparison.RemovedDeprecatedApis) != 1 || comparison.RemovedDeprecatedApis[0] != ingress {
+		t.Fatalf("expected %+v to be removed, got %+v", ingress, comparison.RemovedDeprecatedApis)
+	}
+	if comparison.FromCost.CurrentCpu != 1 || comparison.ToCost.CurrentCpu != 0.75 || comparison.ToCost.RecommendedCpu != 0.6 {
+		t.Fatalf("unexpected cost summaries: %+v -> %+v", comparison.FromCost, comparison.ToCost)
+	}
+}
@@ -15,37 +15,82 @@ import (
 
 func runClusterAnalysis(request *qovery.ClusterAnalysisRequest) {
 	client := utils.GetQoveryClientPanicInCaseOfError()
-	ctx := context.Background()
 
-	analysis, res, err := client.ClustersAPI.
-		StartClusterAnalysis(ctx, clusterAnalysisClusterId).
-		ClusterAnalysisRequest(*request).
-		Execute()
+	analysisId, lastStatus, err := startClusterAnalysis(client, clusterAnalysisClusterId, request, clusterAnalysisWatch)
 	if err != nil {
-		utils.PrintlnError(httpError(res, err))
+		utils.PrintlnError(err)
 		os.Exit(1)
 		panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
 	}
 
-	analysisId := analysis.GetId()
-	utils.Println("Analysis " + pterm.FgBlue.Sprintf("%s", analysisId) + " started (" + string(analysis.GetStatus()) + ")")
-
 	if !clusterAnalysisWatch {
 		utils.PrintlnInfo("Run 'qovery cluster analysis logs --cluster-id " + clusterAnalysisClusterId + " --analysis-id " + analysisId + "' to fetch the report once finished.")
 		return
 	}
 
-	lastStatus := analysis.GetStatus()
-	for !isFinalAnalysisStatus(lastStatus) {
-		time.Sleep(5 * time.Second)
+	if !clusterAnalysisNoLogs {
+		if err := printAnalysisLogs(client, clusterAnalysisClusterId, analysisId); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	}
+
+	if lastStatus != qovery.CLUSTERANALYSISSTATUS_SUCCEEDED {
+		utils.Println(pterm.Error.Sprintf("Analysis %s ended with status %s", analysisId, string(lastStatus)))
+		os.Exit(1)
+		panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+	}
 
-		current, res, err := client.ClustersAPI.GetClusterAnalysis(ctx, clusterAnalysisClusterId, analysisId).Execute()
+	utils.Println(pterm.FgGreen.Sprintf("Analysis %s succeeded", analysisId))
+
+	if clusterAnalysisFailOn == "deprecated" {
+		report, err := getAnalysisReport(client, clusterAnalysisClusterId, analysisId)
 		if err != nil {
-			utils.PrintlnError(httpError(res, err))
+			utils.PrintlnError(err)
 			os.Exit(1)
 			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
 		}
 
+		if report.ParseError != "" {
+			utils.Println(pterm.Error.Sprintf("Cannot check for deprecated APIs: %s", report.ParseError))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if len(report.DeprecatedApis) > 0 {
+			utils.Println(pterm.Error.Sprintf("%d resource(s) still use deprecated APIs", len(report.DeprecatedApis)))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	}
+}
+
+// startClusterAnalysis starts an analysis and, when watch is set, waits for it to reach a final status.
+// It returns the analysis id and its last known status.
+func startClusterAnalysis(client *qovery.APIClient, clusterId string, request *qovery.ClusterAnalysisRequest, watch bool) (string, qovery.ClusterAnalysisStatus, error) {
+	ctx := context.Background()
+
+	analysis, res, err := client.ClustersAPI.
+		StartClusterAnalysis(ctx, clusterId).
+		ClusterAnalysisRequest(*request).
+		Execute()
+	if err != nil {
+		return "", "", httpError(res, err)
+	}
+
+	analysisId := analysis.GetId()
+	utils.Println("Analysis " + pterm.FgBlue.Sprintf("%s", analysisId) + " started (" + string(analysis.GetStatus()) + ")")
+
+	lastStatus := analysis.GetStatus()
+	for watch && !isFinalAnalysisStatus(lastStatus) {
+		time.Sleep(5 * time.Second)
+
+		current, res, err := client.ClustersAPI.GetClusterAnalysis(ctx, clusterId, analysisId).Execute()
+		if err != nil {
+			return analysisId, lastStatus, httpError(res, err)
+		}
+
 		if current.GetStatus() != lastStatus {
 			lastStatus = current.GetStatus()
 			utils.Println("Status: " + string(lastStatus))
@@ -60,21 +105,7 @@ func runClusterAnalysis(request *qovery.ClusterAnalysisRequest) {
 		}
 	}
 
-	if !clusterAnalysisNoLogs {
-		if err := printAnalysisLogs(client, clusterAnalysisClusterId, analysisId); err != nil {
-			utils.PrintlnError(err)
-			os.Exit(1)
-			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
-		}
-	}
-
-	if lastStatus != qovery.CLUSTERANALYSISSTATUS_SUCCEEDED {
-		utils.Println(pterm.Error.Sprintf("Analysis %s ended with status %s", analysisId, string(lastStatus)))
-		os.Exit(1)
-		panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
-	}
-
-	utils.Println(pterm.FgGreen.Sprintf("Analysis %s succeeded", analysisId))
+	return analysisId, lastStatus, nil
 }
 
 func addClusterAnalysisRunFlags(cmd *cobra.Command) {
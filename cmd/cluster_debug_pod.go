@@ -44,7 +44,7 @@ var clusterDebugPodCmd = &cobra.Command{
 			nodeSelector,
 		}
 
-		pkg.ExecShell(&request, "/shell/debug")
+		pkg.ExecShell(&request, "/shell/debug", nil)
 	},
 }
 
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -24,6 +25,19 @@ var clusterDeployCmd = &cobra.Command{
 		}
 
 		client := utils.GetQoveryClient(tokenType, token)
+
+		if waitUntilUnlockedFlag {
+			orgId, targetCluster, err := getClusterContextResource(client, organizationName, clusterName)
+			if err == nil {
+				err = waitUntilClusterUnlocked(client, orgId, targetCluster.Id)
+			}
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+		}
+
 		err = cluster.NewClusterService(client, &promptuifactory.PromptUiFactoryImpl{}).DeployCluster(organizationName, clusterName, watchFlag)
 
 		if err != nil {
@@ -39,6 +53,8 @@ func init() {
 	clusterDeployCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
 	clusterDeployCmd.Flags().StringVarP(&clusterName, "cluster", "n", "", "Cluster Name")
 	clusterDeployCmd.Flags().BoolVarP(&watchFlag, "watch", "w", false, "Watch cluster status until it's ready or an error occurs")
+	clusterDeployCmd.Flags().BoolVarP(&waitUntilUnlockedFlag, "wait-until-unlocked", "", false, "Wait for the cluster to be unlocked before deploying")
+	clusterDeployCmd.Flags().DurationVarP(&waitUntilUnlockedTimeout, "wait-until-unlocked-timeout", "", time.Hour, "Maximum time to wait for the cluster to be unlocked")
 
 	_ = clusterDeployCmd.MarkFlagRequired("cluster")
 }
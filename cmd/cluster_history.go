@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-client-go"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+// clusterDeployment is a cluster deployment rebuilt from the cluster logs.
+type clusterDeployment struct {
+	Action    string
+	Outcome   string
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+var clusterHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List past cluster deployments with their duration and outcome",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		tokenType, token, err := utils.GetAccessToken()
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		client := utils.GetQoveryClient(tokenType, token)
+		orgId, cluster, err := getClusterContextResource(client, organizationName, clusterName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		logs, err := listClusterLogs(client, orgId, cluster.Id)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		deployments := getClusterDeploymentsFromLogs(logs)
+
+		if jsonFlag {
+			utils.Println(getClusterDeploymentsJsonOutput(deployments))
+			return
+		}
+
+		var data [][]string
+		// most recent deployment first, like `environment deployment list`
+		for i := len(deployments) - 1; i >= 0; i-- {
+			deployment := deployments[i]
+			data = append(data, []string{
+				deployment.StartedAt.String(),
+				deployment.Action,
+				getClusterDeploymentOutcomeWithColor(deployment.Outcome),
+				utils.GetDuration(deployment.StartedAt, deployment.EndedAt),
+			})
+		}
+
+		err = utils.PrintTable([]string{"Deployed At", "Action", "Outcome", "Duration"}, data)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	},
+}
+
+// getClusterDeploymentsFromLogs splits the cluster logs into deployments.
+// A deployment starts with the first log following a final step (e.g. Created, PauseError) and
+// its outcome is the last step it reached.
+func getClusterDeploymentsFromLogs(logs []qovery.ClusterLogs) []clusterDeployment {
+	var deployments []clusterDeployment
+	var current *clusterDeployment
+
+	for _, log := range logs {
+		step := string(log.GetStep())
+
+		if current == nil {
+			current = &clusterDeployment{
+				Action:    "Unknown",
+				Outcome:   "InProgress",
+				StartedAt: log.GetTimestamp(),
+			}
+		}
+
+		current.EndedAt = log.GetTimestamp()
+		if action := getClusterDeploymentAction(step); action != "" {
+			current.Action = action
+		}
+
+		if isFinalClusterDeploymentStep(step) {
+			current.Outcome = step
+			deployments = append(deployments, *current)
+			current = nil
+		}
+	}
+
+	if current != nil {
+		deployments = append(deployments, *current)
+	}
+
+	return deployments
+}
+
+func getClusterDeploymentAction(step string) string {
+	switch {
+	case strings.HasPrefix(step, "Create"):
+		return "Deploy"
+	case strings.HasPrefix(step, "Pause"):
+		return "Stop"
+	case strings.HasPrefix(step, "Delete"):
+		return "Delete"
+	default:
+		return ""
+	}
+}
+
+func isFinalClusterDeploymentStep(step string) bool {
+	switch step {
+	case "Created", "CreateError", "Paused", "PauseError", "Deleted", "DeleteError":
+		return true
+	default:
+		return false
+	}
+}
+
+func getClusterDeploymentOutcomeWithColor(outcome string) string {
+	switch {
+	case strings.HasSuffix(outcome, "Error"):
+		return pterm.FgRed.Sprint(outcome)
+	case outcome == "InProgress":
+		return pterm.FgLightBlue.Sprint(outcome)
+	default:
+		return pterm.FgGreen.Sprint(outcome)
+	}
+}
+
+func getClusterDeploymentsJsonOutput(deployments []clusterDeployment) string {
+	var results []interface{}
+
+	for _, deployment := range deployments {
+		results = append(results, map[string]interface{}{
+			"action":                         deployment.Action,
+			"outcome":                        deployment.Outcome,
+			"created_at":                     utils.ToIso8601(&deployment.StartedAt),
+			"deployment_duration_in_seconds": int(deployment.EndedAt.Sub(deployment.StartedAt).Seconds()),
+		})
+	}
+
+	j, err := json.Marshal(results)
+	if err != nil {
+		utils.PrintlnError(err)
+		os.Exit(1)
+		panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+	}
+
+	return string(j)
+}
+
+func init() {
+	clusterCmd.AddCommand(clusterHistoryCmd)
+	clusterHistoryCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	clusterHistoryCmd.Flags().StringVarP(&clusterName, "cluster", "n", "", "Cluster Name")
+	clusterHistoryCmd.Flags().BoolVarP(&jsonFlag, "json", "", false, "JSON output")
+
+	_ = clusterHistoryCmd.MarkFlagRequired("cluster")
+}
@@ -4,14 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/qovery/qovery-cli/pkg/usercontext"
 	"github.com/qovery/qovery-cli/utils"
 	"github.com/qovery/qovery-client-go"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"io"
 	"os"
+	"time"
 )
 
+var waitUntilUnlockedFlag bool
+var waitUntilUnlockedTimeout time.Duration
+
 var clusterLockCmd = &cobra.Command{
 	Use:   "lock",
 	Short: "Lock a cluster",
@@ -78,3 +83,91 @@ func LogDetail(result []byte) {
 		}
 	}
 }
+
+// clusterLockInfo describes the lock currently held on a cluster.
+type clusterLockInfo struct {
+	OwnerName string
+	Reason    string
+	LockedAt  time.Time
+	TtlInDays *int32
+}
+
+// expiresAt returns when the lock expires, or nil if the lock has no TTL.
+func (lock *clusterLockInfo) expiresAt() *time.Time {
+	if lock.TtlInDays == nil {
+		return nil
+	}
+
+	expiresAt := lock.LockedAt.Add(time.Duration(*lock.TtlInDays) * 24 * time.Hour)
+	return &expiresAt
+}
+
+// getClusterLock returns the lock held on the cluster, or nil if the cluster isn't locked.
+func getClusterLock(client *qovery.APIClient, orgId string, clusterId string) (*clusterLockInfo, error) {
+	locks, res, err := client.OrganizationClusterLockAPI.ListClusterLock(context.Background(), orgId).Execute()
+	if err != nil {
+		return nil, httpError(res, err)
+	}
+
+	for _, lock := range locks.Results {
+		if lock.ClusterId == clusterId {
+			return &clusterLockInfo{
+				OwnerName: lock.OwnerName,
+				Reason:    lock.Reason,
+				LockedAt:  lock.LockedAt,
+				TtlInDays: lock.TtlInDays,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// waitUntilClusterUnlocked polls the cluster locks until the cluster is not locked anymore,
+// and fails once waitUntilUnlockedTimeout has elapsed.
+func waitUntilClusterUnlocked(client *qovery.APIClient, orgId string, clusterId string) error {
+	deadline := time.Now().Add(waitUntilUnlockedTimeout)
+	waiting := false
+	for {
+		lock, err := getClusterLock(client, orgId, clusterId)
+		if err != nil {
+			return err
+		}
+
+		if lock == nil {
+			if waiting {
+				utils.Println("Cluster unlocked, resuming..")
+			}
+			return nil
+		}
+
+		if !waiting {
+			waiting = true
+			utils.Println(fmt.Sprintf("Cluster is locked by %s since %s: %s", lock.OwnerName, lock.LockedAt.Format(time.RFC1123), lock.Reason))
+			if expiresAt := lock.expiresAt(); expiresAt != nil {
+				utils.Println(fmt.Sprintf("The lock expires on %s. Waiting for the cluster to be unlocked..", expiresAt.Format(time.RFC1123)))
+			} else {
+				utils.Println("The lock has no expiration. Waiting for the cluster to be unlocked..")
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("cluster %s is still locked after %s", clusterId, waitUntilUnlockedTimeout)
+		}
+
+		// sleep here to avoid too many requests
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// waitUntilEnvironmentClusterUnlockedPanicInCaseOfError waits for the cluster running the environment to be unlocked.
+func waitUntilEnvironmentClusterUnlockedPanicInCaseOfError(client *qovery.APIClient, envId string) {
+	orgId, err := usercontext.GetOrganizationContextResourceId(client, organizationName)
+	checkError(err)
+
+	environment, _, err := client.EnvironmentMainCallsAPI.GetEnvironment(context.Background(), envId).Execute()
+	checkError(err)
+
+	err = waitUntilClusterUnlocked(client, orgId, environment.ClusterId)
+	checkError(err)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-client-go"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var clusterLockExtendCmd = &cobra.Command{
+	Use:   "extend",
+	Short: "Extend the lock of a cluster by locking it again with a new TTL",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if lockTtlInDays < 1 || lockTtlInDays > 5 {
+			utils.PrintlnError(fmt.Errorf("--ttl-in-days must be between 1 and 5 days"))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+
+		lock, err := getClusterLock(client, organizationId, clusterId)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if lock == nil {
+			utils.PrintlnError(fmt.Errorf("cluster %s is not locked", clusterId))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		reason := lock.Reason
+		if lockReason != "" {
+			reason = lockReason
+		}
+
+		lockClusterRequest := qovery.ClusterLockRequest{
+			Reason:    reason,
+			TtlInDays: &lockTtlInDays,
+		}
+
+		_, http, err := client.ClustersAPI.LockCluster(context.Background(), clusterId).ClusterLockRequest(lockClusterRequest).Execute()
+		if err != nil {
+			utils.PrintlnError(err)
+			if http != nil {
+				result, _ := io.ReadAll(http.Body)
+				LogDetail(result)
+			}
+			os.Exit(1)
+		}
+
+		utils.Println(fmt.Sprintf("Cluster lock extended for %d day(s).", lockTtlInDays))
+	},
+}
+
+func init() {
+	clusterLockExtendCmd.Flags().StringVarP(&organizationId, "organization-id", "o", "", "Organization ID")
+	clusterLockExtendCmd.Flags().StringVarP(&clusterId, "cluster-id", "c", "", "Cluster ID")
+	clusterLockExtendCmd.Flags().StringVarP(&lockReason, "reason", "r", "", "New reason (default: keep the current reason)")
+	clusterLockExtendCmd.Flags().Int32VarP(&lockTtlInDays, "ttl-in-days", "d", -1, "New time-to-live (TTL) for the lock in days (1 to 5 days), starting now")
+	_ = clusterLockExtendCmd.MarkFlagRequired("organization-id")
+	_ = clusterLockExtendCmd.MarkFlagRequired("cluster-id")
+	_ = clusterLockExtendCmd.MarkFlagRequired("ttl-in-days")
+
+	clusterLockCmd.AddCommand(clusterLockExtendCmd)
+}
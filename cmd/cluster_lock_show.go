@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var clusterLockShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show who locked a cluster, when, why and the remaining lock time",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+
+		lock, err := getClusterLock(client, organizationId, clusterId)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if lock == nil {
+			utils.Println("Cluster is not locked.")
+			return
+		}
+
+		remaining := "infinite"
+		expiresAt := lock.expiresAt()
+		if expiresAt != nil {
+			remaining = time.Until(*expiresAt).Round(time.Minute).String()
+		}
+
+		err = utils.PrintTable([]string{"Locked By", "Locked At", "Reason", "Remaining TTL"}, [][]string{
+			{lock.OwnerName, lock.LockedAt.Format(time.RFC1123), lock.Reason, remaining},
+		})
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if expiresAt != nil && time.Until(*expiresAt) < 24*time.Hour {
+			utils.Println(pterm.FgYellow.Sprintf("The lock expires in less than a day (%s). Run 'qovery cluster lock extend' to keep it.", expiresAt.Format(time.RFC1123)))
+		}
+	},
+}
+
+func init() {
+	clusterLockShowCmd.Flags().StringVarP(&organizationId, "organization-id", "o", "", "Organization ID")
+	clusterLockShowCmd.Flags().StringVarP(&clusterId, "cluster-id", "c", "", "Cluster ID")
+	_ = clusterLockShowCmd.MarkFlagRequired("organization-id")
+	_ = clusterLockShowCmd.MarkFlagRequired("cluster-id")
+
+	clusterLockCmd.AddCommand(clusterLockShowCmd)
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-client-go"
+
+	"github.com/qovery/qovery-cli/pkg/usercontext"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var (
+	clusterLogsStep   string
+	clusterLogsFollow bool
+)
+
+var clusterLogsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Print cluster deployment logs",
+	Example: `  qovery cluster logs -n my-cluster
+  qovery cluster logs -n my-cluster --step Create --follow`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		tokenType, token, err := utils.GetAccessToken()
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		client := utils.GetQoveryClient(tokenType, token)
+		orgId, cluster, err := getClusterContextResource(client, organizationName, clusterName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		logs, err := listClusterLogs(client, orgId, cluster.Id)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		var lastTimestamp time.Time
+		for _, log := range filterClusterLogsByStep(logs, clusterLogsStep) {
+			printClusterLog(log)
+			lastTimestamp = log.GetTimestamp()
+		}
+
+		if !clusterLogsFollow {
+			return
+		}
+
+		for {
+			status, res, err := client.ClustersAPI.GetClusterStatus(context.Background(), orgId, cluster.Id).Execute()
+			if err != nil {
+				utils.PrintlnError(httpError(res, err))
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+
+			logs, err = listClusterLogs(client, orgId, cluster.Id)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+
+			for _, log := range filterClusterLogsByStep(logs, clusterLogsStep) {
+				if log.GetTimestamp().After(lastTimestamp) {
+					printClusterLog(log)
+					lastTimestamp = log.GetTimestamp()
+				}
+			}
+
+			if utils.IsTerminalClusterState(status.GetStatus()) {
+				utils.Println(fmt.Sprintf("Cluster status: %s", utils.GetClusterStatusTextWithColor(status.GetStatus())))
+				return
+			}
+
+			// sleep here to avoid too many requests
+			time.Sleep(5 * time.Second)
+		}
+	},
+}
+
+// getClusterContextResource resolves the organization and the cluster targeted by the --organization and --cluster flags.
+func getClusterContextResource(client *qovery.APIClient, organizationName string, clusterName string) (string, *qovery.Cluster, error) {
+	orgId, err := usercontext.GetOrganizationContextResourceId(client, organizationName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	clusters, res, err := client.ClustersAPI.ListOrganizationCluster(context.Background(), orgId).Execute()
+	if err != nil {
+		return "", nil, httpError(res, err)
+	}
+
+	cluster := utils.FindByClusterName(clusters.GetResults(), clusterName)
+	if cluster == nil {
+		return "", nil, fmt.Errorf("cluster %s not found. You can list all clusters with: qovery cluster list", clusterName)
+	}
+
+	return orgId, cluster, nil
+}
+
+// listClusterLogs returns the deployment logs of a cluster sorted from the oldest to the newest.
+func listClusterLogs(client *qovery.APIClient, orgId string, clusterId string) ([]qovery.ClusterLogs, error) {
+	logs, res, err := client.ClustersAPI.ListClusterLogs(context.Background(), orgId, clusterId).Execute()
+	if err != nil {
+		return nil, httpError(res, err)
+	}
+
+	results := logs.GetResults()
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].GetTimestamp().Before(results[j].GetTimestamp())
+	})
+
+	return results, nil
+}
+
+func filterClusterLogsByStep(logs []qovery.ClusterLogs, step string) []qovery.ClusterLogs {
+	if step == "" {
+		return logs
+	}
+
+	var filteredLogs []qovery.ClusterLogs
+	for _, log := range logs {
+		if strings.EqualFold(string(log.GetStep()), step) {
+			filteredLogs = append(filteredLogs, log)
+		}
+	}
+
+	return filteredLogs
+}
+
+func printClusterLog(log qovery.ClusterLogs) {
+	message := log.GetMessage()
+	line := fmt.Sprintf("| %s | %s | %s", log.GetTimestamp().Format("2006-01-02 15:04:05.000"), log.GetStep(), message.GetSafeMessage())
+
+	if strings.EqualFold(log.GetType(), "error") || strings.HasSuffix(string(log.GetStep()), "Error") {
+		line = pterm.FgRed.Sprint(line)
+	}
+
+	utils.Println(line)
+}
+
+func init() {
+	clusterCmd.AddCommand(clusterLogsCmd)
+	clusterLogsCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	clusterLogsCmd.Flags().StringVarP(&clusterName, "cluster", "n", "", "Cluster Name")
+	clusterLogsCmd.Flags().StringVarP(&clusterLogsStep, "step", "", "", "Only show logs of this step (e.g. LoadConfiguration, Create, Pause, Delete)")
+	clusterLogsCmd.Flags().BoolVarP(&clusterLogsFollow, "follow", "f", false, "Stream new logs until the cluster reaches a terminal state")
+
+	_ = clusterLogsCmd.MarkFlagRequired("cluster")
+}
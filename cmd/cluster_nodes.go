@@ -69,8 +69,34 @@ type ListNodesRequest struct {
 	OrganizationID utils.Id `url:"organization"`
 	ClusterID      utils.Id `url:"cluster"`
 }
+
+// NodeResponse is a node of the /cluster/nodes payload. Only Name is always sent: the other fields are omitted by
+// the engines which do not report them, and are then displayed as unknown.
 type NodeResponse struct {
-	Name string
+	Name         string
+	InstanceType *string           `json:"instance_type,omitempty"`
+	Conditions   []NodeCondition   `json:"conditions,omitempty"`
+	Allocatable  *NodeResources    `json:"resources_allocatable,omitempty"`
+	Allocated    *NodeResources    `json:"resources_allocated,omitempty"`
+	Pods         []NodePodResponse `json:"pods,omitempty"`
+}
+type NodeCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+type NodeResources struct {
+	CpuMilli  uint64 `json:"cpu_milli"`
+	MemoryMib uint64 `json:"memory_mib"`
+	Pods      uint64 `json:"pods"`
+}
+type NodePodResponse struct {
+	Name             string  `json:"name"`
+	Namespace        string  `json:"namespace"`
+	ServiceId        string  `json:"qovery_service_id"`
+	EnvironmentId    string  `json:"qovery_environment_id"`
+	CpuMilliRequest  *uint64 `json:"cpu_milli_request,omitempty"`
+	MemoryMibRequest *uint64 `json:"memory_mib_request,omitempty"`
 }
 type ListNodeResponse struct {
 	Nodes []NodeResponse
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/pkg/cluster"
+	"github.com/qovery/qovery-cli/pkg/promptuifactory"
+	"github.com/qovery/qovery-cli/pkg/usercontext"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var clusterSecretManagerCmd = &cobra.Command{
+	Use:   "secret-manager",
+	Short: "Manage the secret manager accesses of a cluster",
+	Long: `Manage the secret manager accesses of a cluster: AWS Secrets Manager, AWS Parameter Store or GCP Secret Manager.
+The external secrets of the services running on the cluster reference their value in one of these secret managers.
+
+The CLI can list the accesses, but only add the first access of a cluster and remove its last one: the API does not return
+the credentials of the accesses, which would be lost by editing the cluster. The credentials of an access can't be tested
+either: 'lint' only checks the access exists and the references to it are well-formed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if len(args) == 0 {
+			_ = cmd.Help()
+			os.Exit(0)
+		}
+	},
+}
+
+// getSecretManagerCluster returns the organization id and the cluster named by --organization and --cluster.
+func getSecretManagerCluster(client *qovery.APIClient) (string, *qovery.Cluster, error) {
+	organizationId, err := usercontext.GetOrganizationContextResourceId(client, organizationName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	clusters, err := cluster.NewClusterService(client, &promptuifactory.PromptUiFactoryImpl{}).ListClusters(organizationId)
+	if err != nil {
+		return "", nil, err
+	}
+
+	c := utils.FindByClusterName(clusters.GetResults(), clusterName)
+	if c == nil {
+		return "", nil, fmt.Errorf("cluster %s not found", clusterName)
+	}
+
+	return organizationId, c, nil
+}
+
+func init() {
+	clusterCmd.AddCommand(clusterSecretManagerCmd)
+}
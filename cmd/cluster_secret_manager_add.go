@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var (
+	secretManagerName            string
+	secretManagerKind            string
+	secretManagerRegion          string
+	secretManagerRoleArn         string
+	secretManagerAccessKeyId     string
+	secretManagerSecretAccessKey string
+	secretManagerProjectId       string
+	secretManagerCredentialsFile string
+)
+
+// secretManagerKinds maps the --kind values to the kinds of secret manager
+var secretManagerKinds = map[string]string{
+	"aws-secrets-manager": pkg.SecretManagerAwsSecretsManager,
+	"aws-parameter-store": pkg.SecretManagerAwsParameterStore,
+	"gcp-secret-manager":  pkg.SecretManagerGcpSecretManager,
+}
+
+var clusterSecretManagerAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a secret manager access to a cluster",
+	Long: `Add a secret manager access to a cluster.
+AWS Secrets Manager and AWS Parameter Store are accessed with --role-arn, or with --access-key-id and --secret-access-key, in --region.
+GCP Secret Manager is accessed with the JSON service account key of --credentials-file in --project-id.
+
+Limitations:
+  - the accesses are edited through the cluster edit request and the API does not return the credentials of the existing
+    accesses, so an access can only be added to a cluster which has none yet
+  - on a cluster which already has an access, the command fails without changing anything: add it from the Qovery console`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		kind, ok := secretManagerKinds[strings.ToLower(secretManagerKind)]
+		if !ok {
+			utils.PrintlnError(fmt.Errorf("unknown kind %s, expected aws-secrets-manager, aws-parameter-store or gcp-secret-manager", secretManagerKind))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		credentials := ""
+		if secretManagerCredentialsFile != "" {
+			content, err := os.ReadFile(secretManagerCredentialsFile)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			credentials = string(content)
+		}
+
+		access, err := pkg.SecretManagerAccessRequest{
+			Name:            secretManagerName,
+			Kind:            kind,
+			Region:          secretManagerRegion,
+			RoleArn:         secretManagerRoleArn,
+			AccessKeyId:     secretManagerAccessKeyId,
+			SecretAccessKey: secretManagerSecretAccessKey,
+			ProjectId:       secretManagerProjectId,
+			JsonCredentials: credentials,
+		}.ToJson()
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		organizationId, cluster, err := getSecretManagerCluster(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		document, err := pkg.GetClusterDocument(organizationId, cluster.Id)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if err := pkg.AddSecretManagerAccess(document, access); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if err := pkg.EditClusterDocument(organizationId, cluster.Id, document); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		utils.Println(fmt.Sprintf("Secret manager access %s added to cluster %s, redeploy the cluster to apply it", secretManagerName, cluster.Name))
+	},
+}
+
+func init() {
+	clusterSecretManagerCmd.AddCommand(clusterSecretManagerAddCmd)
+	clusterSecretManagerAddCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	clusterSecretManagerAddCmd.Flags().StringVarP(&clusterName, "cluster", "n", "", "Cluster Name")
+	clusterSecretManagerAddCmd.Flags().StringVarP(&secretManagerName, "name", "", "", "Secret manager access name")
+	clusterSecretManagerAddCmd.Flags().StringVarP(&secretManagerKind, "kind", "", "", "Secret manager kind: aws-secrets-manager, aws-parameter-store or gcp-secret-manager")
+	clusterSecretManagerAddCmd.Flags().StringVarP(&secretManagerRegion, "region", "", "", "AWS region of the secret manager")
+	clusterSecretManagerAddCmd.Flags().StringVarP(&secretManagerRoleArn, "role-arn", "", "", "ARN of the AWS role to assume to access the secret manager")
+	clusterSecretManagerAddCmd.Flags().StringVarP(&secretManagerAccessKeyId, "access-key-id", "", "", "AWS access key id")
+	clusterSecretManagerAddCmd.Flags().StringVarP(&secretManagerSecretAccessKey, "secret-access-key", "", "", "AWS secret access key")
+	clusterSecretManagerAddCmd.Flags().StringVarP(&secretManagerProjectId, "project-id", "", "", "GCP project id of the secret manager")
+	clusterSecretManagerAddCmd.Flags().StringVarP(&secretManagerCredentialsFile, "credentials-file", "", "", "GCP service account JSON key file")
+	clusterSecretManagerAddCmd.Example = "qovery cluster secret-manager add --cluster <cluster_name> --name aws --kind aws-secrets-manager --region eu-west-3 --role-arn arn:aws:iam::123456789012:role/qovery-secrets\n" +
+		"qovery cluster secret-manager add --cluster <cluster_name> --name gcp --kind gcp-secret-manager --project-id my-project --credentials-file key.json"
+
+	_ = clusterSecretManagerAddCmd.MarkFlagRequired("cluster")
+	_ = clusterSecretManagerAddCmd.MarkFlagRequired("name")
+	_ = clusterSecretManagerAddCmd.MarkFlagRequired("kind")
+}
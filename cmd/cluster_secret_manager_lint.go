@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var clusterSecretManagerLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check a secret manager access is attached to a cluster and the references to it are well-formed",
+	Long: `Check that a secret manager access is attached to the cluster and, when --environment is set, that the external secrets
+of the environment and its services referencing it are well-formed.
+
+This does not test the credentials of the access nor that the referenced secrets exist: the API does not read the secret
+managers, so wrong credentials or a reference to a missing secret are only reported when a service is deployed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		organizationId, cluster, err := getSecretManagerCluster(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		document, err := pkg.GetClusterDocument(organizationId, cluster.Id)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		accesses, err := pkg.GetSecretManagerAccesses(document)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		var access *pkg.SecretManagerAccess
+		for i := range accesses {
+			if accesses[i].Name == secretManagerName {
+				access = &accesses[i]
+			}
+		}
+		if access == nil {
+			utils.PrintlnError(fmt.Errorf("secret manager access %s not found on cluster %s", secretManagerName, cluster.Name))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		utils.Println(fmt.Sprintf("Secret manager access %s (%s) is attached to cluster %s", access.Name, access.Kind, cluster.Name))
+
+		if environmentName == "" {
+			return
+		}
+
+		_, _, environmentId, err := getOrganizationProjectEnvironmentContextResourcesIds(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		secrets, err := listExternalSecrets(client, organizationId, environmentId)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		invalid := 0
+		var data [][]string
+		for _, secret := range secrets {
+			if secret.SecretManagerAccessId != access.Id {
+				continue
+			}
+			status := pterm.FgGreen.Sprint("Well-formed")
+			if problems := lintExternalSecretReference(secret); len(problems) > 0 {
+				invalid++
+				status = pterm.FgRed.Sprint(strings.Join(problems, "; "))
+			}
+			data = append(data, []string{secret.Key, secret.Scope, getExternalSecretServiceColumn(secret), secret.Reference, status})
+		}
+
+		if len(data) == 0 {
+			utils.Println("No external secret of the environment references this secret manager access")
+			return
+		}
+
+		err = utils.PrintTable([]string{"Key", "Scope", "Service", "Reference", "Status"}, data)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if invalid > 0 {
+			utils.PrintlnError(fmt.Errorf("%d of %d external secret references are malformed", invalid, len(data)))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	},
+}
+
+func init() {
+	clusterSecretManagerCmd.AddCommand(clusterSecretManagerLintCmd)
+	clusterSecretManagerLintCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	clusterSecretManagerLintCmd.Flags().StringVarP(&clusterName, "cluster", "n", "", "Cluster Name")
+	clusterSecretManagerLintCmd.Flags().StringVarP(&secretManagerName, "name", "", "", "Secret manager access name")
+	clusterSecretManagerLintCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	clusterSecretManagerLintCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+	clusterSecretManagerLintCmd.Example = "qovery cluster secret-manager lint --cluster <cluster_name> --name aws\n" +
+		"qovery cluster secret-manager lint --cluster <cluster_name> --name aws --project <project_name> --environment <environment_name>"
+
+	_ = clusterSecretManagerLintCmd.MarkFlagRequired("cluster")
+	_ = clusterSecretManagerLintCmd.MarkFlagRequired("name")
+}
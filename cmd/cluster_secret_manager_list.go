@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var clusterSecretManagerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the secret manager accesses of a cluster",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		organizationId, cluster, err := getSecretManagerCluster(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		document, err := pkg.GetClusterDocument(organizationId, cluster.Id)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		accesses, err := pkg.GetSecretManagerAccesses(document)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if jsonFlag {
+			j, err := json.Marshal(accesses)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			utils.Println(string(j))
+			return
+		}
+
+		var data [][]string
+		for _, access := range accesses {
+			data = append(data, []string{access.Id, access.Name, access.Kind})
+		}
+
+		err = utils.PrintTable([]string{"Id", "Name", "Kind"}, data)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	},
+}
+
+func init() {
+	clusterSecretManagerCmd.AddCommand(clusterSecretManagerListCmd)
+	clusterSecretManagerListCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	clusterSecretManagerListCmd.Flags().StringVarP(&clusterName, "cluster", "n", "", "Cluster Name")
+	clusterSecretManagerListCmd.Flags().BoolVarP(&jsonFlag, "json", "", false, "JSON output")
+	clusterSecretManagerListCmd.Example = "qovery cluster secret-manager list --cluster <cluster_name>"
+
+	_ = clusterSecretManagerListCmd.MarkFlagRequired("cluster")
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var secretManagerRemoveYes bool
+
+var clusterSecretManagerRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a secret manager access from a cluster",
+	Long: `Remove a secret manager access from a cluster.
+The external secrets referencing it can't be resolved anymore, check them with 'qovery external-secret list' before removing it.
+
+Limitations:
+  - the accesses are edited through the cluster edit request and the API does not return the credentials of the accesses
+    which are kept, so only the last access of a cluster can be removed
+  - on a cluster with several accesses, the command fails without changing anything: remove it from the Qovery console`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		organizationId, cluster, err := getSecretManagerCluster(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		document, err := pkg.GetClusterDocument(organizationId, cluster.Id)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if err := pkg.RemoveSecretManagerAccess(document, secretManagerName); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if !secretManagerRemoveYes {
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				utils.PrintlnError(errors.New("--yes is required to remove the secret manager access when not running in a terminal"))
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			if !utils.Validate("secret manager access") {
+				return
+			}
+		}
+
+		if err := pkg.EditClusterDocument(organizationId, cluster.Id, document); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		utils.Println(fmt.Sprintf("Secret manager access %s removed from cluster %s, redeploy the cluster to apply it", secretManagerName, cluster.Name))
+	},
+}
+
+func init() {
+	clusterSecretManagerCmd.AddCommand(clusterSecretManagerRemoveCmd)
+	clusterSecretManagerRemoveCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	clusterSecretManagerRemoveCmd.Flags().StringVarP(&clusterName, "cluster", "n", "", "Cluster Name")
+	clusterSecretManagerRemoveCmd.Flags().StringVarP(&secretManagerName, "name", "", "", "Secret manager access name")
+	clusterSecretManagerRemoveCmd.Flags().BoolVarP(&secretManagerRemoveYes, "yes", "y", false, "Remove without confirmation")
+	clusterSecretManagerRemoveCmd.Example = "qovery cluster secret-manager remove --cluster <cluster_name> --name aws"
+
+	_ = clusterSecretManagerRemoveCmd.MarkFlagRequired("cluster")
+	_ = clusterSecretManagerRemoveCmd.MarkFlagRequired("name")
+}
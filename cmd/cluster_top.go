@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-client-go"
+
+	"github.com/qovery/qovery-cli/pkg/usercontext"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var clusterTopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show cluster nodes resources usage and the Qovery services running on them",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		tokenType, token, err := utils.GetAccessToken()
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		client := utils.GetQoveryClient(tokenType, token)
+		organizationId, err := usercontext.GetOrganizationContextResourceId(client, organizationName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		names, err := getClusterServiceNames(client, organizationId, clusterId)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		request := ListNodesRequest{
+			utils.Id(organizationId),
+			utils.Id(clusterId),
+		}
+
+		for {
+			nodes, err := ExecListNodes(&request)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+
+			if err := printClusterTop(nodes, names); err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+
+			if !watchFlag {
+				return
+			}
+
+			// sleep here to avoid too many requests
+			time.Sleep(5 * time.Second)
+			utils.Println(fmt.Sprintf("\n--- %s ---", time.Now().Format(time.RFC1123)))
+		}
+	},
+}
+
+// getClusterServiceNames returns the names of the environments running on the cluster and of their services, by id.
+func getClusterServiceNames(client *qovery.APIClient, organizationId string, clusterId string) (map[string]string, error) {
+	environments, err := listClusterEnvironments(client, organizationId, clusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	for _, environment := range environments {
+		names[environment.Id] = environment.Name
+
+		services, err := utils.GetEnvironmentServiceNamesById(client, environment.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, name := range services {
+			names[id] = name
+		}
+	}
+
+	return names, nil
+}
+
+func printClusterTop(nodes *ListNodeResponse, names map[string]string) error {
+	var nodeData [][]string
+	var podData [][]string
+
+	for _, node := range nodes.Nodes {
+		instanceType := unknownNodeValue
+		if node.InstanceType != nil {
+			instanceType = *node.InstanceType
+		}
+
+		var cpuRequested, memoryRequested, podCount, cpuAllocatable, memoryAllocatable, podAllocatable *uint64
+		if node.Allocated != nil {
+			cpuRequested, memoryRequested = &node.Allocated.CpuMilli, &node.Allocated.MemoryMib
+		} else {
+			cpuRequested, memoryRequested = sumPodRequests(node.Pods)
+		}
+		if node.Pods != nil {
+			count := uint64(len(node.Pods))
+			podCount = &count
+		}
+		if node.Allocatable != nil {
+			cpuAllocatable, memoryAllocatable, podAllocatable = &node.Allocatable.CpuMilli, &node.Allocatable.MemoryMib, &node.Allocatable.Pods
+		}
+
+		nodeData = append(nodeData, []string{
+			node.Name,
+			instanceType,
+			formatNodeUsage(cpuRequested, cpuAllocatable, "m"),
+			formatNodeUsage(memoryRequested, memoryAllocatable, "Mi"),
+			formatNodeUsage(podCount, podAllocatable, ""),
+			getNodeConditionsText(node.Conditions),
+		})
+
+		podData = append(podData, getNodeWorkloadRows(node, names)...)
+	}
+
+	if err := utils.PrintTable([]string{"Node", "Instance Type", "CPU Requests", "Memory Requests", "Pods", "Conditions"}, nodeData); err != nil {
+		return err
+	}
+
+	utils.Println("")
+
+	return utils.PrintTable([]string{"Node", "Environment", "Service", "Pods", "CPU Requests", "Memory Requests"}, podData)
+}
+
+// unknownNodeValue is displayed for the node fields not reported by the cluster
+const unknownNodeValue = "unknown"
+
+// sumPodRequests returns the CPU and memory requested by the pods, or nil when a pod does not report its requests.
+func sumPodRequests(pods []NodePodResponse) (*uint64, *uint64) {
+	if pods == nil {
+		return nil, nil
+	}
+
+	var cpuMilli, memoryMib uint64
+	cpuKnown, memoryKnown := true, true
+	for _, pod := range pods {
+		if pod.CpuMilliRequest == nil {
+			cpuKnown = false
+		} else {
+			cpuMilli += *pod.CpuMilliRequest
+		}
+		if pod.MemoryMibRequest == nil {
+			memoryKnown = false
+		} else {
+			memoryMib += *pod.MemoryMibRequest
+		}
+	}
+
+	var cpu, memory *uint64
+	if cpuKnown {
+		cpu = &cpuMilli
+	}
+	if memoryKnown {
+		memory = &memoryMib
+	}
+	return cpu, memory
+}
+
+// getNodeWorkloadRows groups the pods of a node by Qovery environment and service.
+// Pods not managed by Qovery are grouped by namespace.
+func getNodeWorkloadRows(node NodeResponse, names map[string]string) [][]string {
+	type workload struct {
+		environment string
+		service     string
+	}
+
+	podsByWorkload := make(map[workload][]NodePodResponse)
+	var workloads []workload
+	for _, pod := range node.Pods {
+		w := workload{environment: pod.Namespace, service: "-"}
+		if pod.EnvironmentId != "" {
+			w.environment = getNameOrId(names, pod.EnvironmentId)
+		}
+		if pod.ServiceId != "" {
+			w.service = getNameOrId(names, pod.ServiceId)
+		}
+
+		if _, ok := podsByWorkload[w]; !ok {
+			workloads = append(workloads, w)
+		}
+		podsByWorkload[w] = append(podsByWorkload[w], pod)
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].environment != workloads[j].environment {
+			return workloads[i].environment < workloads[j].environment
+		}
+		return workloads[i].service < workloads[j].service
+	})
+
+	var rows [][]string
+	for _, w := range workloads {
+		pods := podsByWorkload[w]
+		cpuMilli, memoryMib := sumPodRequests(pods)
+		rows = append(rows, []string{node.Name, w.environment, w.service, fmt.Sprintf("%d", len(pods)), formatNodeUsage(cpuMilli, nil, "m"), formatNodeUsage(memoryMib, nil, "Mi")})
+	}
+
+	return rows
+}
+
+func getNameOrId(names map[string]string, id string) string {
+	if name, ok := names[id]; ok && name != "" {
+		return name
+	}
+	return id
+}
+
+// formatNodeUsage returns the requested amount out of the allocatable one. Either may be nil when not reported by the cluster.
+func formatNodeUsage(requested *uint64, allocatable *uint64, unit string) string {
+	if requested == nil {
+		return unknownNodeValue
+	}
+	if allocatable == nil || *allocatable == 0 {
+		return fmt.Sprintf("%d%s", *requested, unit)
+	}
+
+	percentage := *requested * 100 / *allocatable
+	usage := fmt.Sprintf("%d%s / %d%s (%d%%)", *requested, unit, *allocatable, unit, percentage)
+	switch {
+	case percentage >= 90:
+		return pterm.FgRed.Sprint(usage)
+	case percentage >= 75:
+		return pterm.FgYellow.Sprint(usage)
+	default:
+		return usage
+	}
+}
+
+// getNodeConditionsText lists the node conditions which are not healthy: Ready should be True, pressure conditions should be False.
+func getNodeConditionsText(conditions []NodeCondition) string {
+	if conditions == nil {
+		return unknownNodeValue
+	}
+
+	var unhealthy []string
+	for _, condition := range conditions {
+		healthy := condition.Status == "False"
+		if condition.Type == "Ready" {
+			healthy = condition.Status == "True"
+		}
+
+		if !healthy {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s=%s", condition.Type, condition.Status))
+		}
+	}
+
+	if len(unhealthy) == 0 {
+		return pterm.FgGreen.Sprint("Healthy")
+	}
+
+	return pterm.FgRed.Sprint(strings.Join(unhealthy, ", "))
+}
+
+func init() {
+	clusterCmd.AddCommand(clusterTopCmd)
+	clusterTopCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	clusterTopCmd.Flags().StringVarP(&clusterId, "cluster-id", "c", "", "Cluster ID")
+	clusterTopCmd.Flags().BoolVarP(&watchFlag, "watch", "w", false, "Refresh the view every 5 seconds")
+
+	_ = clusterTopCmd.MarkFlagRequired("cluster-id")
+}
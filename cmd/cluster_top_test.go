@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pterm/pterm"
+)
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+func TestFormatNodeUsage(t *testing.T) {
+	tests := []struct {
+		name        string
+		requested   *uint64
+		allocatable *uint64
+		unit        string
+		expected    string
+	}{
+		{
+			name:     "requests not reported",
+			unit:     "m",
+			expected: "unknown",
+		},
+		{
+			name:      "allocatable not reported",
+			requested: uint64Ptr(500),
+			unit:      "m",
+			expected:  "500m",
+		},
+		{
+			name:        "nothing allocatable",
+			requested:   uint64Ptr(500),
+			allocatable: uint64Ptr(0),
+			unit:        "m",
+			expected:    "500m",
+		},
+		{
+			name:        "low usage",
+			requested:   uint64Ptr(1000),
+			allocatable: uint64Ptr(4000),
+			unit:        "m",
+			expected:    "1000m / 4000m (25%)",
+		},
+		{
+			name:        "high usage",
+			requested:   uint64Ptr(3000),
+			allocatable: uint64Ptr(4000),
+			unit:        "Mi",
+			expected:    pterm.FgYellow.Sprint("3000Mi / 4000Mi (75%)"),
+		},
+		{
+			name:        "critical usage",
+			requested:   uint64Ptr(58),
+			allocatable: uint64Ptr(58),
+			expected:    pterm.FgRed.Sprint("58 / 58 (100%)"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatNodeUsage(tt.requested, tt.allocatable, tt.unit)
+			if got != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetNodeConditionsText(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []NodeCondition
+		expected   string
+	}{
+		{
+			name:     "conditions not reported",
+			expected: "unknown",
+		},
+		{
+			name: "healthy",
+			conditions: []NodeCondition{
+				{Type: "Ready", Status: "True"},
+				{Type: "MemoryPressure", Status: "False"},
+				{Type: "DiskPressure", Status: "False"},
+			},
+			expected: pterm.FgGreen.Sprint("Healthy"),
+		},
+		{
+			name: "not ready and under pressure",
+			conditions: []NodeCondition{
+				{Type: "Ready", Status: "False"},
+				{Type: "MemoryPressure", Status: "True"},
+				{Type: "DiskPressure", Status: "False"},
+			},
+			expected: pterm.FgRed.Sprint("Ready=False, MemoryPressure=True"),
+		},
+		{
+			name: "unknown status",
+			conditions: []NodeCondition{
+				{Type: "Ready", Status: "Unknown"},
+			},
+			expected: pterm.FgRed.Sprint("Ready=Unknown"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getNodeConditionsText(tt.conditions)
+			if got != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetNodeWorkloadRows(t *testing.T) {
+	names := map[string]string{
+		"env-1": "staging",
+		"svc-1": "api",
+		"svc-2": "",
+	}
+
+	tests := []struct {
+		name     string
+		node     NodeResponse
+		expected [][]string
+	}{
+		{
+			name: "pods not reported",
+			node: NodeResponse{Name: "node-1"},
+		},
+		{
+			name: "pods grouped by environment and service",
+			node: NodeResponse{
+				Name: "node-1",
+				Pods: []NodePodResponse{
+					{Name: "api-1", EnvironmentId: "env-1", ServiceId: "svc-1", CpuMilliRequest: uint64Ptr(100), MemoryMibRequest: uint64Ptr(256)},
+					{Name: "coredns", Namespace: "kube-system", CpuMilliRequest: uint64Ptr(50), MemoryMibRequest: uint64Ptr(64)},
+					{Name: "api-2", EnvironmentId: "env-1", ServiceId: "svc-1", CpuMilliRequest: uint64Ptr(100), MemoryMibRequest: uint64Ptr(256)},
+					{Name: "worker-1", EnvironmentId: "env-1", ServiceId: "svc-2", CpuMilliRequest: uint64Ptr(200), MemoryMibRequest: uint64Ptr(512)},
+				},
+			},
+			expected: [][]string{
+				{"node-1", "kube-system", "-", "1", "50m", "64Mi"},
+				{"node-1", "staging", "api", "2", "200m", "512Mi"},
+				{"node-1", "staging", "svc-2", "1", "200m", "512Mi"},
+			},
+		},
+		{
+			name: "unknown environment and requests not reported",
+			node: NodeResponse{
+				Name: "node-2",
+				Pods: []NodePodResponse{
+					{Name: "api-1", EnvironmentId: "env-2", ServiceId: "svc-1", CpuMilliRequest: uint64Ptr(100)},
+					{Name: "api-2", EnvironmentId: "env-2", ServiceId: "svc-1"},
+				},
+			},
+			expected: [][]string{
+				{"node-2", "env-2", "api", "2", "unknown", "unknown"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getNodeWorkloadRows(tt.node, names)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
+
+	"github.com/qovery/qovery-client-go"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+// preflightCheck is the result of one of the checks run before upgrading a cluster.
+type preflightCheck struct {
+	Name    string
+	Passed  bool
+	Details []string
+}
+
+// runClusterUpgradePreflight checks that a cluster can safely be upgraded to targetVersion.
+func runClusterUpgradePreflight(client *qovery.APIClient, orgId string, cluster *qovery.Cluster, targetVersion string) []preflightCheck {
+	environments, envErr := listClusterEnvironments(client, orgId, cluster.Id)
+
+	return []preflightCheck{
+		checkDeprecatedApis(client, cluster.Id, targetVersion),
+		checkEnvironmentsInTerminalState(client, environments, envErr),
+		checkClusterNotLocked(client, orgId, cluster.Id),
+		checkHelmChartsKubeVersion(client, orgId, environments, envErr, targetVersion),
+	}
+}
+
+// printPreflightReport prints the checks and returns true when all of them passed.
+func printPreflightReport(checks []preflightCheck) bool {
+	goForUpgrade := true
+	var data [][]string
+
+	for _, check := range checks {
+		result := pterm.FgGreen.Sprint("OK")
+		if !check.Passed {
+			result = pterm.FgRed.Sprint("KO")
+			goForUpgrade = false
+		}
+		data = append(data, []string{check.Name, result, strings.Join(check.Details, "\n")})
+	}
+
+	if err := utils.PrintTable([]string{"Check", "Result", "Details"}, data); err != nil {
+		utils.PrintlnError(err)
+	}
+
+	if goForUpgrade {
+		utils.Println(pterm.FgGreen.Sprint("GO: the cluster is ready to be upgraded"))
+	} else {
+		utils.Println(pterm.FgRed.Sprint("NO GO: fix the failed checks or use --force to upgrade anyway"))
+	}
+
+	return goForUpgrade
+}
+
+func checkDeprecatedApis(client *qovery.APIClient, clusterId string, targetVersion string) preflightCheck {
+	check := preflightCheck{Name: "No deprecated API removed in " + targetVersion}
+
+	request := qovery.NewClusterAnalysisRequest(qovery.CLUSTERANALYSISKIND_DEPRECATED_API_CHECK, qovery.CLUSTERANALYSISOUTPUTFORMAT_JSON)
+	request.SetTargetKubernetesVersion(targetVersion)
+
+	analysisId, status, err := startClusterAnalysis(client, clusterId, request, true)
+	if err != nil {
+		check.Details = []string{err.Error()}
+		return check
+	}
+
+	if status != qovery.CLUSTERANALYSISSTATUS_SUCCEEDED {
+		check.Details = []string{fmt.Sprintf("analysis %s ended with status %s", analysisId, status)}
+		return check
+	}
+
+	report, err := getAnalysisReport(client, clusterId, analysisId)
+	if err != nil {
+		check.Details = []string{err.Error()}
+		return check
+	}
+
+	if report.ParseError != "" {
+		check.Details = []string{report.ParseError}
+		return check
+	}
+
+	for _, finding := range report.DeprecatedApis {
+		check.Details = append(check.Details, fmt.Sprintf("%s %s/%s uses %s", finding.Kind, finding.Namespace, finding.Name, finding.ApiVersion))
+	}
+	check.Passed = len(report.DeprecatedApis) == 0
+
+	return check
+}
+
+func checkEnvironmentsInTerminalState(client *qovery.APIClient, environments []qovery.Environment, envErr error) preflightCheck {
+	check := preflightCheck{Name: "No deployment in progress on the cluster"}
+	if envErr != nil {
+		check.Details = []string{envErr.Error()}
+		return check
+	}
+
+	for _, environment := range environments {
+		statuses, res, err := client.EnvironmentMainCallsAPI.GetEnvironmentStatuses(context.Background(), environment.Id).Execute()
+		if err != nil {
+			check.Details = append(check.Details, fmt.Sprintf("%s: %s", environment.Name, httpError(res, err)))
+			continue
+		}
+
+		envStatus := statuses.GetEnvironment()
+		if !utils.IsTerminalState(envStatus.State) {
+			check.Details = append(check.Details, fmt.Sprintf("%s is %s", environment.Name, envStatus.State))
+		}
+	}
+	check.Passed = len(check.Details) == 0
+
+	return check
+}
+
+func checkClusterNotLocked(client *qovery.APIClient, orgId string, clusterId string) preflightCheck {
+	check := preflightCheck{Name: "Cluster is not locked"}
+
+	locks, res, err := client.OrganizationClusterLockAPI.ListClusterLock(context.Background(), orgId).Execute()
+	if err != nil {
+		check.Details = []string{httpError(res, err).Error()}
+		return check
+	}
+
+	for _, lock := range locks.Results {
+		if lock.ClusterId == clusterId {
+			check.Details = append(check.Details, fmt.Sprintf("locked by %s on %s: %s", lock.OwnerName, lock.LockedAt.Format(time.RFC1123), lock.Reason))
+		}
+	}
+	check.Passed = len(check.Details) == 0
+
+	return check
+}
+
+func checkHelmChartsKubeVersion(client *qovery.APIClient, orgId string, environments []qovery.Environment, envErr error, targetVersion string) preflightCheck {
+	check := preflightCheck{Name: "Helm charts support " + targetVersion}
+	if envErr != nil {
+		check.Details = []string{envErr.Error()}
+		return check
+	}
+
+	version, err := semver.NewVersion(targetVersion)
+	if err != nil {
+		check.Details = []string{fmt.Sprintf("invalid kubernetes version %s: %s", targetVersion, err)}
+		return check
+	}
+
+	incompatible := 0
+	for _, environment := range environments {
+		helms, res, err := client.HelmsAPI.ListHelms(context.Background(), environment.Id).Execute()
+		if err != nil {
+			check.Details = append(check.Details, fmt.Sprintf("%s: %s", environment.Name, httpError(res, err)))
+			incompatible++
+			continue
+		}
+
+		for _, helm := range helms.GetResults() {
+			repository := utils.GetHelmRepository(&helm)
+			if repository == nil {
+				// charts from git repositories can't be inspected without cloning them
+				continue
+			}
+
+			kubeVersion, err := getHelmChartKubeVersion(client, orgId, repository)
+			if issue := getHelmChartKubeVersionIssue(kubeVersion, err, version, repository.ChartName, repository.ChartVersion); issue != "" {
+				incompatible++
+				check.Details = append(check.Details, fmt.Sprintf("%s/%s: %s", environment.Name, helm.Name, issue))
+			}
+		}
+	}
+	check.Passed = incompatible == 0
+
+	return check
+}
+
+// getHelmChartKubeVersionIssue returns why the chart declaring kubeVersion, read with err, is not known to support version,
+// or an empty string when it does. A kubeVersion that can't be read or parsed is an issue: the check fails closed.
+func getHelmChartKubeVersionIssue(kubeVersion string, err error, version *semver.Version, chartName string, chartVersion string) string {
+	if err != nil {
+		return fmt.Sprintf("cannot read chart kubeVersion: %s", err)
+	}
+	if kubeVersion == "" {
+		return ""
+	}
+
+	constraint, err := semver.NewConstraint(kubeVersion)
+	if err != nil {
+		return fmt.Sprintf("invalid kubeVersion %q", kubeVersion)
+	}
+	if !constraint.Check(version) {
+		return fmt.Sprintf("chart %s %s requires kubeVersion %s", chartName, chartVersion, kubeVersion)
+	}
+	return ""
+}
+
+// getHelmChartKubeVersion reads the kubeVersion declared by a chart from the index of its helm repository.
+func getHelmChartKubeVersion(client *qovery.APIClient, orgId string, source *qovery.HelmSourceRepositoryResponse) (string, error) {
+	repository, res, err := client.HelmRepositoriesAPI.GetHelmRepository(context.Background(), orgId, source.Repository.Id).Execute()
+	if err != nil {
+		return "", httpError(res, err)
+	}
+
+	repositoryUrl := repository.GetUrl()
+	if !strings.HasPrefix(repositoryUrl, "http") {
+		// OCI registries don't expose an index
+		return "", nil
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	indexResponse, err := httpClient.Get(strings.TrimSuffix(repositoryUrl, "/") + "/index.yaml")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = indexResponse.Body.Close() }()
+
+	if indexResponse.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cannot download repository index: %s", indexResponse.Status)
+	}
+
+	body, err := io.ReadAll(indexResponse.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return getKubeVersionFromHelmIndex(body, source.ChartName, source.ChartVersion)
+}
+
+func getKubeVersionFromHelmIndex(index []byte, chartName string, chartVersion string) (string, error) {
+	var repositoryIndex struct {
+		Entries map[string][]struct {
+			Version     string `yaml:"version"`
+			KubeVersion string `yaml:"kubeVersion"`
+		} `yaml:"entries"`
+	}
+
+	if err := yaml.Unmarshal(index, &repositoryIndex); err != nil {
+		return "", err
+	}
+
+	for _, chart := range repositoryIndex.Entries[chartName] {
+		if chart.Version == chartVersion {
+			return chart.KubeVersion, nil
+		}
+	}
+
+	return "", fmt.Errorf("chart %s %s not found in repository index", chartName, chartVersion)
+}
+
+// listClusterEnvironments returns all the environments of the organization running on the cluster.
+func listClusterEnvironments(client *qovery.APIClient, orgId string, clusterId string) ([]qovery.Environment, error) {
+	projects, res, err := client.ProjectsAPI.ListProject(context.Background(), orgId).Execute()
+	if err != nil {
+		return nil, httpError(res, err)
+	}
+
+	var clusterEnvironments []qovery.Environment
+	for _, project := range projects.GetResults() {
+		environments, res, err := client.EnvironmentsAPI.ListEnvironment(context.Background(), project.Id).Execute()
+		if err != nil {
+			return nil, httpError(res, err)
+		}
+
+		for _, environment := range environments.GetResults() {
+			if environment.ClusterId == clusterId {
+				clusterEnvironments = append(clusterEnvironments, environment)
+			}
+		}
+	}
+
+	return clusterEnvironments, nil
+}
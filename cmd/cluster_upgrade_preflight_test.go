@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+func TestGetKubeVersionFromHelmIndex(t *testing.T) {
+	index := []byte(`apiVersion: v1
+entries:
+  redis:
+    - version: 18.0.0
+      kubeVersion: ">=1.23.0-0"
+    - version: 17.0.0
+`)
+
+	tests := []struct {
+		name     string
+		version  string
+		expected string
+		wantErr  bool
+	}{
+		{name: "chart declaring a kubeVersion", version: "18.0.0", expected: ">=1.23.0-0"},
+		{name: "chart without kubeVersion", version: "17.0.0", expected: ""},
+		{name: "unknown chart version", version: "1.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getKubeVersionFromHelmIndex(index, "redis", tt.version)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetHelmChartKubeVersionIssue(t *testing.T) {
+	version := semver.MustParse("1.30")
+
+	tests := []struct {
+		name        string
+		kubeVersion string
+		err         error
+		expected    string
+	}{
+		{name: "no kubeVersion declared", expected: ""},
+		{name: "compatible chart", kubeVersion: ">=1.23.0-0", expected: ""},
+		{name: "incompatible chart", kubeVersion: "<1.29.0-0", expected: "chart redis 18.0.0 requires kubeVersion <1.29.0-0"},
+		{name: "unreadable index", err: errors.New("404 Not Found"), expected: "cannot read chart kubeVersion: 404 Not Found"},
+		{name: "invalid constraint", kubeVersion: "not a version", expected: `invalid kubeVersion "not a version"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getHelmChartKubeVersionIssue(tt.kubeVersion, tt.err, version, "redis", "18.0.0")
+			if got != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -67,7 +67,19 @@ var clusterUpgradeCmd = &cobra.Command{
 			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
 		}
 
-		utils.Println(fmt.Sprintf("A new kubernetes version `%s` is available for your cluster %s." /**status.NextK8sAvailableVersion.Get()*/, "", clusterName))
+		nextVersion := status.GetNextK8sAvailableVersion()
+		utils.Println(fmt.Sprintf("A new kubernetes version `%s` is available for your cluster %s.", nextVersion, clusterName))
+
+		utils.Println("Running pre-upgrade checks..")
+		checks := runClusterUpgradePreflight(client, orgId, cluster, nextVersion)
+		if !printPreflightReport(checks) {
+			if !forceUpgrade {
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			utils.Println("--force is set, upgrading despite failed checks..")
+		}
+
 		if !proceedWithoutConfirmation {
 			prompt := promptui.Select{
 				Label: "Do you want to proceed with cluster upgrade? [Yes/No]",
@@ -127,6 +139,7 @@ var clusterUpgradeCmd = &cobra.Command{
 }
 
 var proceedWithoutConfirmation bool = false
+var forceUpgrade bool = false
 
 func init() {
 	clusterCmd.AddCommand(clusterUpgradeCmd)
@@ -134,6 +147,7 @@ func init() {
 	clusterUpgradeCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
 	clusterUpgradeCmd.Flags().StringVarP(&clusterName, "cluster", "n", "", "Cluster Name")
 	clusterUpgradeCmd.Flags().BoolVarP(&watchFlag, "watch", "w", false, "Watch cluster status until it's ready or an error occurs")
+	clusterUpgradeCmd.Flags().BoolVarP(&forceUpgrade, "force", "", false, "Upgrade even if the pre-upgrade checks failed")
 
 	_ = clusterUpgradeCmd.MarkFlagRequired("cluster")
 }
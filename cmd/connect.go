@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/qovery/qovery-client-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var (
+	connectMode       string
+	connectSocksPort  uint16
+	connectWriteHosts bool
+	connectHostsFile  string
+)
+
+const hostsFileBlockBegin = "# BEGIN qovery connect"
+const hostsFileBlockEnd = "# END qovery connect"
+
+// builtInHostInternalPattern matches the built-in variables holding the internal host of a service, i.e: QOVERY_POSTGRESQL_Z1A2B3C4D_HOST_INTERNAL
+var builtInHostInternalPattern = regexp.MustCompile(`^QOVERY_[A-Z_]+_(Z[A-Z0-9]+)_HOST_INTERNAL$`)
+
+// connectTarget is a service of the environment reachable from the local machine.
+type connectTarget struct {
+	ServiceName string
+	Host        string
+	Ports       []uint16
+	Request     pkg.PortForwardRequest
+	// LocalAddress is the loopback address the host resolves to in hosts mode
+	LocalAddress string
+}
+
+var connectCmd = &cobra.Command{
+	Use:   "connect",
+	Short: "Expose the services of an environment on their internal host names",
+	Long: `Forward every port of every service of the environment, databases included, so that their internal host names
+(the QOVERY_*_HOST_INTERNAL built-in variables) can be used from the local machine. This lets you run one service locally against the rest of the remote environment.
+
+Two modes are available:
+- hosts: each service gets its own loopback address and its ports are forwarded on it. The matching /etc/hosts entries are printed, or written with --write-hosts and removed on exit.
+- socks5: a SOCKS5 proxy resolves the internal host names, point your application to it (i.e: ALL_PROXY=socks5h://localhost:1080).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if connectMode != "hosts" && connectMode != "socks5" {
+			utils.PrintlnError(errors.New("--mode must be 'hosts' or 'socks5'"))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		organizationId, projectId, environmentId, err := getOrganizationProjectEnvironmentContextResourcesIds(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		targets, err := getConnectTargets(client, organizationId, projectId, environmentId)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		if len(targets) == 0 {
+			utils.PrintlnError(errors.New("no service with an internal host and an exposed port found in the environment"))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		metrics := &pkg.PortForwardMetrics{}
+		if connectMode == "socks5" {
+			startConnectSocks5(targets, metrics)
+		} else {
+			startConnectHosts(targets, metrics)
+		}
+
+		done := make(chan os.Signal, 1)
+		signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
+		<-done
+
+		if connectMode == "hosts" && connectWriteHosts {
+			if err := writeHostsFileBlock(connectHostsFile, nil); err != nil {
+				utils.PrintlnError(fmt.Errorf("cannot remove the entries from %s: %w", connectHostsFile, err))
+			}
+		}
+	},
+}
+
+// getConnectTargets lists the services of the environment with their internal host and the ports exposed by their pods.
+func getConnectTargets(client *qovery.APIClient, organizationId string, projectId string, environmentId string) ([]connectTarget, error) {
+	environment, err := utils.GetEnvironmentById(environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := utils.GetEnvironmentServicesById(environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	variables, err := utils.ListEnvironmentVariables(client, environmentId)
+	if err != nil {
+		return nil, err
+	}
+	hosts := getInternalHostsByShortId(variables)
+
+	names, err := utils.GetEnvironmentServiceNamesById(client, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []connectTarget
+	for _, service := range services {
+		name := getNameOrId(names, service.ID)
+		host, ok := hosts[getServiceShortId(service.ID)]
+		if !ok {
+			log.Warnf("%s: no internal host found, skipping it", name)
+			continue
+		}
+
+		request := pkg.PortForwardRequest{
+			ServiceID:      utils.Id(service.ID),
+			ServiceType:    strings.ToUpper(string(service.Type)),
+			ProjectID:      utils.Id(projectId),
+			OrganizationID: utils.Id(organizationId),
+			EnvironmentID:  utils.Id(environmentId),
+			ClusterID:      environment.ClusterID,
+		}
+
+		pods, err := pkg.ExecListPods(&request)
+		if err != nil {
+			log.Warnf("%s: cannot list the pods, skipping it: %v", name, err)
+			continue
+		}
+
+		var ports []uint16
+		for _, pod := range pods.Pods {
+			for _, port := range pod.Ports {
+				if !slices.Contains(ports, port) {
+					ports = append(ports, port)
+				}
+			}
+		}
+		if len(ports) == 0 {
+			log.Warnf("%s: no running pod exposes a port, skipping it", name)
+			continue
+		}
+		slices.Sort(ports)
+
+		targets = append(targets, connectTarget{ServiceName: name, Host: host, Ports: ports, Request: request})
+	}
+
+	return targets, nil
+}
+
+// getInternalHostsByShortId returns the internal hosts found in the built-in variables, by service short id.
+func getInternalHostsByShortId(variables []qovery.VariableResponse) map[string]string {
+	hosts := make(map[string]string)
+	for _, variable := range variables {
+		match := builtInHostInternalPattern.FindStringSubmatch(variable.Key)
+		if match == nil || !variable.Value.IsSet() || variable.Value.Get() == nil {
+			continue
+		}
+		hosts[match[1]] = *variable.Value.Get()
+	}
+
+	return hosts
+}
+
+// getServiceShortId returns the id used by Qovery in the built-in variable names: Z followed by the first block of the id.
+func getServiceShortId(id string) string {
+	firstBlock, _, _ := strings.Cut(id, "-")
+	return "Z" + strings.ToUpper(firstBlock)
+}
+
+// startConnectHosts forwards the ports of each target on its own loopback address.
+func startConnectHosts(targets []connectTarget, metrics *pkg.PortForwardMetrics) {
+	var entries []string
+	var data [][]string
+	for i := range targets {
+		target := &targets[i]
+		target.LocalAddress = fmt.Sprintf("127.0.0.%d", i+2)
+		entries = append(entries, fmt.Sprintf("%s %s", target.LocalAddress, target.Host))
+
+		for _, port := range target.Ports {
+			req := target.Request
+			req.ListenAddress = target.LocalAddress
+			req.LocalPort = port
+			req.Port = port
+			go pkg.ExecPortForward(&req, metrics)
+			data = append(data, []string{target.ServiceName, net.JoinHostPort(target.Host, strconv.Itoa(int(port))), net.JoinHostPort(target.LocalAddress, strconv.Itoa(int(port)))})
+		}
+	}
+
+	_ = utils.PrintTable([]string{"Service", "Internal Address", "Local Address"}, data)
+
+	if runtime.GOOS == "darwin" {
+		utils.PrintlnInfo("On macOS, only 127.0.0.1 is enabled by default, add the other loopback addresses with: sudo ifconfig lo0 alias <address> up")
+	}
+
+	if connectWriteHosts {
+		if err := writeHostsFileBlock(connectHostsFile, entries); err != nil {
+			utils.PrintlnError(fmt.Errorf("cannot write %s, run the command as administrator or add the entries manually: %w", connectHostsFile, err))
+		} else {
+			utils.Println(fmt.Sprintf("Entries added to %s, they are removed on exit", connectHostsFile))
+			return
+		}
+	}
+
+	utils.Println(fmt.Sprintf("Add these entries to %s:", connectHostsFile))
+	utils.Println(strings.Join(entries, "\n"))
+}
+
+// startConnectSocks5 runs a SOCKS5 proxy resolving the internal hosts of the targets.
+func startConnectSocks5(targets []connectTarget, metrics *pkg.PortForwardMetrics) {
+	byHost := make(map[string]connectTarget)
+	var data [][]string
+	for _, target := range targets {
+		byHost[strings.ToLower(target.Host)] = target
+		for _, port := range target.Ports {
+			data = append(data, []string{target.ServiceName, net.JoinHostPort(target.Host, strconv.Itoa(int(port)))})
+		}
+	}
+
+	resolve := func(host string, port uint16) (*pkg.PortForwardRequest, bool) {
+		target, ok := byHost[strings.ToLower(strings.TrimSuffix(host, "."))]
+		if !ok || !slices.Contains(target.Ports, port) {
+			return nil, false
+		}
+
+		req := target.Request
+		req.Port = port
+		return &req, true
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(int(connectSocksPort))))
+	if err != nil {
+		utils.PrintlnError(err)
+		os.Exit(1)
+		panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+	}
+
+	go func() {
+		if err := pkg.ServeSocks5(listener, resolve, metrics); err != nil {
+			log.Error("socks5 proxy stopped: ", err)
+		}
+	}()
+
+	_ = utils.PrintTable([]string{"Service", "Internal Address"}, data)
+	utils.Println(fmt.Sprintf("SOCKS5 proxy listening on %s, i.e: ALL_PROXY=socks5h://%s", listener.Addr().String(), listener.Addr().String()))
+}
+
+// writeHostsFileBlock replaces the qovery connect entries of the hosts file by entries, or removes them if entries is empty.
+func writeHostsFileBlock(hostsFile string, entries []string) error {
+	content, err := os.ReadFile(hostsFile)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(hostsFile)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(hostsFile, []byte(replaceHostsFileBlock(string(content), entries)), info.Mode().Perm())
+}
+
+func replaceHostsFileBlock(content string, entries []string) string {
+	var lines []string
+	inBlock := false
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		switch {
+		case strings.TrimSpace(line) == hostsFileBlockBegin:
+			inBlock = true
+		case strings.TrimSpace(line) == hostsFileBlockEnd:
+			inBlock = false
+		case !inBlock:
+			lines = append(lines, line)
+		}
+	}
+
+	if len(entries) > 0 {
+		lines = append(lines, hostsFileBlockBegin)
+		lines = append(lines, entries...)
+		lines = append(lines, hostsFileBlockEnd)
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func getDefaultHostsFile() string {
+	if runtime.GOOS == "windows" {
+		return `C:\Windows\System32\drivers\etc\hosts`
+	}
+	return "/etc/hosts"
+}
+
+func init() {
+	connectCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	connectCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	connectCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+	connectCmd.Flags().StringVar(&connectMode, "mode", "hosts", "how internal host names are resolved: 'hosts' (loopback addresses and hosts file entries) or 'socks5' (local SOCKS5 proxy)")
+	connectCmd.Flags().Uint16Var(&connectSocksPort, "socks-port", 1080, "local port of the SOCKS5 proxy")
+	connectCmd.Flags().BoolVar(&connectWriteHosts, "write-hosts", false, "add the entries to the hosts file and remove them on exit, requires administrator rights")
+	connectCmd.Flags().StringVar(&connectHostsFile, "hosts-file", getDefaultHostsFile(), "hosts file to write the entries to")
+	connectCmd.Example = "qovery connect\n" +
+		"sudo qovery connect --write-hosts --organization <organization_name> --project <project_name> --environment <environment_name>\n" +
+		"qovery connect --mode socks5 --socks-port 1080"
+
+	rootCmd.AddCommand(connectCmd)
+}
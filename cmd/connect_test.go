@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestReplaceHostsFileBlock(t *testing.T) {
+	hosts := "127.0.0.1 localhost\n"
+
+	added := replaceHostsFileBlock(hosts, []string{"127.0.0.2 app-z1a2b3c4d"})
+	expected := "127.0.0.1 localhost\n# BEGIN qovery connect\n127.0.0.2 app-z1a2b3c4d\n# END qovery connect\n"
+	if added != expected {
+		t.Fatalf("expected %q, got %q", expected, added)
+	}
+
+	replaced := replaceHostsFileBlock(added, []string{"127.0.0.2 db-z5e6f7a8b"})
+	expected = "127.0.0.1 localhost\n# BEGIN qovery connect\n127.0.0.2 db-z5e6f7a8b\n# END qovery connect\n"
+	if replaced != expected {
+		t.Fatalf("expected %q, got %q", expected, replaced)
+	}
+
+	if removed := replaceHostsFileBlock(replaced, nil); removed != hosts {
+		t.Fatalf("expected %q, got %q", hosts, removed)
+	}
+}
+
+func TestGetServiceShortId(t *testing.T) {
+	if got := getServiceShortId("1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d"); got != "Z1A2B3C4D" {
+		t.Fatalf("expected Z1A2B3C4D, got %s", got)
+	}
+}
@@ -50,6 +50,8 @@ var containerEnvCreateCmd = &cobra.Command{
 			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
 		}
 
+		utils.IsSecret = proposeSecret(utils.Key, utils.Value, utils.IsSecret)
+
 		err = utils.CreateServiceVariable(client, projectId, envId, container.Id, utils.ContainerScope, utils.Key, utils.Value, utils.IsSecret)
 
 		if err != nil {
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var cpCmd = &cobra.Command{
+	Use:   "cp <service>:<path> <local_path> | <local_path> <service>:<path>",
+	Short: "Copy files and directories to and from a service container",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		service, remotePath, localPath, download, err := parseCopyArgs(args[0], args[1])
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		serviceName = service
+
+		shellRequest, err := shellRequestWithContextFlags()
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if download {
+			err = pkg.CopyFromPod(shellRequest, remotePath, localPath)
+		} else {
+			err = pkg.CopyToPod(shellRequest, localPath, remotePath)
+		}
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	},
+}
+
+// parseCopyArgs finds which of source and destination is the remote `<service>:<path>` one.
+// download is true when the remote path is the source.
+func parseCopyArgs(source string, destination string) (service string, remotePath string, localPath string, download bool, err error) {
+	sourceService, sourcePath, sourceIsRemote := splitRemoteCopyPath(source)
+	destinationService, destinationPath, destinationIsRemote := splitRemoteCopyPath(destination)
+
+	switch {
+	case sourceIsRemote && destinationIsRemote:
+		return "", "", "", false, errors.New("copying between two services is not supported")
+	case sourceIsRemote:
+		return sourceService, sourcePath, destination, true, nil
+	case destinationIsRemote:
+		return destinationService, destinationPath, source, false, nil
+	default:
+		return "", "", "", false, errors.New("one of the paths must be prefixed by the service name: <service>:<path>")
+	}
+}
+
+func splitRemoteCopyPath(arg string) (string, string, bool) {
+	service, remotePath, found := strings.Cut(arg, ":")
+	// a local path can contain a colon, a remote one must be absolute
+	if !found || service == "" || strings.ContainsAny(service, `/\`) || !strings.HasPrefix(remotePath, "/") {
+		return "", "", false
+	}
+
+	return service, remotePath, true
+}
+
+func init() {
+	cpCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	cpCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	cpCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+	cpCmd.Flags().StringVarP(&podName, "pod", "p", "", "pod name where to copy from or to")
+	cpCmd.Flags().StringVar(&podContainerName, "container", "", "container name inside the pod")
+	cpCmd.Example = "qovery cp my-service:/app/logs/output.log ./output.log\n" +
+		"qovery cp ./config my-service:/app/config --pod my-service-6d8f9c7b5-x2x4k --container app\n" +
+		"qovery cp my-service:/tmp/dump --organization <organization_name> --project <project_name> --environment <environment_name> ./dump"
+
+	rootCmd.AddCommand(cpCmd)
+}
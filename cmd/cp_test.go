@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestParseCopyArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		destination string
+		service     string
+		remotePath  string
+		localPath   string
+		download    bool
+		wantErr     bool
+	}{
+		{name: "download", source: "api:/app/out.log", destination: "./out.log", service: "api", remotePath: "/app/out.log", localPath: "./out.log", download: true},
+		{name: "upload", source: "./config", destination: "api:/app/config", service: "api", remotePath: "/app/config", localPath: "./config"},
+		{name: "windows local path", source: `C:\config`, destination: "api:/app/config", service: "api", remotePath: "/app/config", localPath: `C:\config`},
+		{name: "no remote path", source: "./a", destination: "./b", wantErr: true},
+		{name: "two remote paths", source: "api:/a", destination: "worker:/b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, remotePath, localPath, download, err := parseCopyArgs(tt.source, tt.destination)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if service != tt.service || remotePath != tt.remotePath || localPath != tt.localPath || download != tt.download {
+				t.Fatalf("got (%s, %s, %s, %t)", service, remotePath, localPath, download)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/pkg/usercontext"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+// variableAuditFinding is a variable which is not a secret but looks like one.
+type variableAuditFinding struct {
+	Project     string `json:"project"`
+	Environment string `json:"environment,omitempty"`
+	Scope       string `json:"scope"`
+	Service     string `json:"service,omitempty"`
+	Key         string `json:"key"`
+	Reason      string `json:"reason"`
+}
+
+var envAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Find the environment variables of an organization looking like secrets",
+	Long: `Scan the environment variables of the projects, environments and services of an organization and report the ones
+which are not secrets but look like credentials: AWS keys, GitHub tokens, private keys, JWTs, database URLs with a password
+or high entropy values. The values are never printed. The command exits with an error if a variable is reported.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		organizationId, err := usercontext.GetOrganizationContextResourceId(client, organizationName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		findings, err := auditOrganizationVariables(client, organizationId, projectName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if jsonFlag {
+			j, err := json.Marshal(findings)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			utils.Println(string(j))
+		} else if len(findings) > 0 {
+			var data [][]string
+			for _, finding := range findings {
+				data = append(data, []string{finding.Project, finding.Environment, getScopeSource(finding.Scope), finding.Service, finding.Key, finding.Reason})
+			}
+
+			err = utils.PrintTable([]string{"Project", "Environment", "Scope", "Service", "Key", "Reason"}, data)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+		}
+
+		if len(findings) > 0 {
+			utils.PrintlnError(fmt.Errorf("%d variables look like secrets, store them as secrets or external secrets", len(findings)))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		if !jsonFlag {
+			utils.Println("No variable looks like a secret")
+		}
+	},
+}
+
+// auditOrganizationVariables scans the variables of the projects of the organization, or of the project named projectName only.
+func auditOrganizationVariables(client *qovery.APIClient, organizationId string, projectName string) ([]variableAuditFinding, error) {
+	projects, _, err := client.ProjectsAPI.ListProject(context.Background(), organizationId).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []variableAuditFinding
+	for _, project := range projects.GetResults() {
+		if projectName != "" && project.Name != projectName {
+			continue
+		}
+
+		// the project and environment variables are listed again with each environment and service, report them once
+		seen := make(map[string]bool)
+
+		variables, err := utils.ListProjectVariables(client, project.Id)
+		if err != nil {
+			return nil, err
+		}
+		var projectVariables []utils.EnvVarLineOutput
+		for _, variable := range variables {
+			projectVariables = append(projectVariables, utils.FromEnvironmentVariableToEnvVarLineOutput(variable))
+		}
+		findings = append(findings, auditVariables(project.Name, "", projectVariables, seen)...)
+
+		environments, _, err := client.EnvironmentsAPI.ListEnvironment(context.Background(), project.Id).Execute()
+		if err != nil {
+			return nil, err
+		}
+		for _, environment := range environments.GetResults() {
+			environmentVariables, err := listEnvironmentAndServicesVariables(client, environment.Id)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, auditVariables(project.Name, environment.Name, environmentVariables, seen)...)
+		}
+	}
+
+	return findings, nil
+}
+
+// auditVariables returns the variables looking like secrets, sorted by scope, service and key.
+// Secrets, external secrets, aliases, built-in variables and the variables already seen are skipped.
+func auditVariables(project string, environment string, variables []utils.EnvVarLineOutput, seen map[string]bool) []variableAuditFinding {
+	var findings []variableAuditFinding
+	for _, variable := range variables {
+		if seen[variable.Id] {
+			continue
+		}
+		seen[variable.Id] = true
+
+		if variable.IsSecret || variable.SecretManagerAccessId != nil || variable.AliasParentKey != nil ||
+			variable.Value == nil || variable.Scope == string(qovery.APIVARIABLESCOPEENUM_BUILT_IN) {
+			continue
+		}
+
+		reason := detectSecret(variable.Key, *variable.Value)
+		if reason == "" {
+			continue
+		}
+
+		finding := variableAuditFinding{
+			Project: project,
+			Scope:   variable.Scope,
+			Key:     variable.Key,
+			Reason:  reason,
+		}
+		if variable.Scope != string(qovery.APIVARIABLESCOPEENUM_PROJECT) {
+			finding.Environment = environment
+		}
+		if variable.Service != nil {
+			finding.Service = *variable.Service
+		}
+		findings = append(findings, finding)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Scope != findings[j].Scope {
+			return utils.ScopePriority(findings[i].Scope) < utils.ScopePriority(findings[j].Scope)
+		}
+		if findings[i].Service != findings[j].Service {
+			return findings[i].Service < findings[j].Service
+		}
+		return findings[i].Key < findings[j].Key
+	})
+
+	return findings
+}
+
+func init() {
+	envCmd.AddCommand(envAuditCmd)
+	envAuditCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	envAuditCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name, all the projects of the organization by default")
+	envAuditCmd.Flags().BoolVarP(&jsonFlag, "json", "", false, "JSON output")
+	envAuditCmd.Example = "qovery env audit\n" +
+		"qovery env audit --organization <organization_name> --project <project_name> --json"
+}
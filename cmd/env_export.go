@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var (
+	envExportFormat     string
+	envExportScope      string
+	envExportResolve    bool
+	envExportOutputFile string
+	envExportSecretName string
+)
+
+var envExportFormats = []string{"dotenv", "shell", "json", "yaml", "k8s-secret", "docker-env"}
+
+var envExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export environment variables of a project, an environment or a service",
+	Long: `Export environment variables of a project, an environment or a service.
+Without --resolve, only the variables defined at the scope are exported, with their raw value: aliases are exported as a {{PARENT_KEY}} reference.
+With --resolve, all the variables visible at the scope are exported with their effective value: overrides, aliases and interpolations are resolved.
+The values of secrets and external secrets are not returned by the API: they are skipped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if !slices.Contains(envExportFormats, envExportFormat) {
+			utils.PrintlnError(fmt.Errorf("--format must be one of %s", strings.Join(envExportFormats, ", ")))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		variables, name, err := listVariablesByScope(client, envExportScope)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		exported, skippedSecrets := getExportedVariables(variables, envExportScope, envExportResolve)
+		if len(skippedSecrets) > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "Secrets skipped, their value can't be fetched: %s\n", strings.Join(skippedSecrets, ", "))
+		}
+
+		secretName := envExportSecretName
+		if secretName == "" {
+			secretName = name
+		}
+
+		output, err := formatExportedValues(exported, envExportFormat, secretName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if envExportOutputFile == "" {
+			fmt.Print(output)
+			return
+		}
+
+		if err := os.WriteFile(envExportOutputFile, []byte(output), 0600); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		utils.PrintlnInfo(fmt.Sprintf("%d variables exported to %s", len(exported), envExportOutputFile))
+	},
+}
+
+// listVariablesByScope lists the variables visible at the scope, and returns the name of the project, environment or service.
+func listVariablesByScope(client *qovery.APIClient, scope string) ([]utils.EnvVarLineOutput, string, error) {
+	organizationId, projectId, environmentId, err := getOrganizationProjectEnvironmentContextResourcesIds(client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var variablesResponse []qovery.VariableResponse
+	var name string
+	switch scope {
+	case "project":
+		project, err := utils.GetProjectById(projectId)
+		if err != nil {
+			return nil, "", err
+		}
+		name = string(project.Name)
+		variablesResponse, err = utils.ListProjectVariables(client, projectId)
+		if err != nil {
+			return nil, "", err
+		}
+	case "environment":
+		environment, err := utils.GetEnvironmentById(environmentId)
+		if err != nil {
+			return nil, "", err
+		}
+		name = string(environment.Name)
+		variablesResponse, err = utils.ListEnvironmentVariables(client, environmentId)
+		if err != nil {
+			return nil, "", err
+		}
+	case "service":
+		service, err := getServiceContextResourceId(client, serviceName, environmentId)
+		if err != nil {
+			return nil, "", err
+		}
+		if service == nil {
+			return nil, "", fmt.Errorf("service %s not found in organization %s", serviceName, organizationId)
+		}
+		name = string(service.Name)
+		variablesResponse, err = utils.ListServiceVariables(client, string(service.ID), service.Type)
+		if err != nil {
+			return nil, "", err
+		}
+	default:
+		return nil, "", fmt.Errorf("--scope must be 'project', 'environment' or 'service'")
+	}
+
+	var variables []utils.EnvVarLineOutput
+	for _, variable := range variablesResponse {
+		variables = append(variables, utils.FromEnvironmentVariableToEnvVarLineOutput(variable))
+	}
+
+	return variables, name, nil
+}
+
+// getExportedVariables returns the variables to export, with the value to export, and the secrets skipped because their value is unknown.
+func getExportedVariables(variables []utils.EnvVarLineOutput, scope string, resolve bool) ([]utils.EnvVarLineOutput, []string) {
+	var exported []utils.EnvVarLineOutput
+	var skippedSecrets []string
+
+	// external secrets are returned with the path of the secret in the secret manager as value, their value is unknown too
+	variables = slices.Clone(variables)
+	for i := range variables {
+		if variables[i].SecretManagerAccessId != nil {
+			variables[i].Value = nil
+		}
+	}
+
+	resolved := utils.ResolveVariables(variables)
+	for _, variable := range utils.EffectiveVariables(variables) {
+		if resolve {
+			variable.Value = resolved[variable.Key]
+		} else if !isVariableOfScope(variable, scope) {
+			continue
+		} else if variable.AliasParentKey != nil {
+			reference := fmt.Sprintf("{{%s}}", *variable.AliasParentKey)
+			variable.Value = &reference
+		}
+
+		if variable.Value == nil {
+			skippedSecrets = append(skippedSecrets, variable.Key)
+			continue
+		}
+		exported = append(exported, variable)
+	}
+
+	return exported, skippedSecrets
+}
+
+func isVariableOfScope(variable utils.EnvVarLineOutput, scope string) bool {
+	switch qovery.APIVariableScopeEnum(variable.Scope) {
+	case qovery.APIVARIABLESCOPEENUM_BUILT_IN:
+		return false
+	case qovery.APIVARIABLESCOPEENUM_PROJECT:
+		return scope == "project"
+	case qovery.APIVARIABLESCOPEENUM_ENVIRONMENT:
+		return scope == "environment"
+	default:
+		return scope == "service"
+	}
+}
+
+// formatExportedValues writes the variables in format. The json format is the one of the env list commands.
+func formatExportedValues(variables []utils.EnvVarLineOutput, format string, secretName string) (string, error) {
+	values := make(map[string]string)
+	keys := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		values[variable.Key] = *variable.Value
+		keys = append(keys, variable.Key)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	switch format {
+	case "dotenv":
+		content, err := godotenv.Marshal(values)
+		if err != nil {
+			return "", err
+		}
+		if content != "" {
+			builder.WriteString(content + "\n")
+		}
+	case "shell":
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf("export %s=%s\n", key, singleQuote(values[key])))
+		}
+	case "docker-env":
+		// docker env files don't support quotes nor multi-line values
+		for _, key := range keys {
+			if strings.ContainsAny(values[key], "\r\n") {
+				return "", fmt.Errorf("%s is a multi-line value which is not supported by the docker-env format", key)
+			}
+			builder.WriteString(fmt.Sprintf("%s=%s\n", key, values[key]))
+		}
+	case "json":
+		builder.WriteString(utils.GetEnvVarJsonOutput(variables, true) + "\n")
+	case "yaml":
+		content, err := yaml.Marshal(values)
+		if err != nil {
+			return "", err
+		}
+		builder.Write(content)
+	case "k8s-secret":
+		secret := map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]string{"name": getKubernetesName(secretName)},
+			"type":       "Opaque",
+			"stringData": values,
+		}
+		content, err := yaml.Marshal(secret)
+		if err != nil {
+			return "", err
+		}
+		builder.Write(content)
+	default:
+		return "", fmt.Errorf("unknown format %s", format)
+	}
+
+	return builder.String(), nil
+}
+
+// singleQuote quotes value for POSIX shells.
+func singleQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// getKubernetesName turns name into a valid Kubernetes resource name.
+func getKubernetesName(name string) string {
+	var builder strings.Builder
+	for _, char := range strings.ToLower(name) {
+		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '-' || char == '.' {
+			builder.WriteRune(char)
+		} else {
+			builder.WriteRune('-')
+		}
+	}
+
+	kubernetesName := strings.Trim(builder.String(), "-.")
+	if kubernetesName == "" {
+		return "qovery-env"
+	}
+	return kubernetesName
+}
+
+func init() {
+	envCmd.AddCommand(envExportCmd)
+	envExportCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	envExportCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	envExportCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+	envExportCmd.Flags().StringVarP(&serviceName, "service", "", "", "Service Name")
+	envExportCmd.Flags().StringVarP(&envExportFormat, "format", "f", "dotenv", "Output format: "+strings.Join(envExportFormats, ", "))
+	envExportCmd.Flags().StringVarP(&envExportScope, "scope", "", "service", "Scope of the variables to export: project, environment or service")
+	envExportCmd.Flags().BoolVarP(&envExportResolve, "resolve", "", false, "Export the effective values: resolve overrides, aliases and interpolations")
+	envExportCmd.Flags().StringVarP(&envExportOutputFile, "output", "o", "", "Write the variables to this file instead of stdout")
+	envExportCmd.Flags().StringVarP(&envExportSecretName, "secret-name", "", "", "Name of the Kubernetes Secret, defaults to the name of the project, environment or service")
+	envExportCmd.Example = "qovery env export --service <service_name> --resolve > .env\n" +
+		"qovery env export --scope environment --format shell\n" +
+		"qovery env export --service <service_name> --resolve --format k8s-secret --secret-name my-app -o secret.yaml"
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+func exportedVariables(values ...string) []utils.EnvVarLineOutput {
+	var variables []utils.EnvVarLineOutput
+	for i := 0; i < len(values); i += 2 {
+		value := values[i+1]
+		variables = append(variables, utils.EnvVarLineOutput{Key: values[i], Value: &value, Scope: "APPLICATION"})
+	}
+	return variables
+}
+
+func TestFormatExportedValues(t *testing.T) {
+	variables := exportedVariables("PORT", "8080", "DATABASE_URL", "postgresql://user:pa'ss@db:5432/app")
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format:   "shell",
+			expected: "export DATABASE_URL='postgresql://user:pa'\\''ss@db:5432/app'\nexport PORT='8080'\n",
+		},
+		{
+			format:   "docker-env",
+			expected: "DATABASE_URL=postgresql://user:pa'ss@db:5432/app\nPORT=8080\n",
+		},
+		{
+			format:   "dotenv",
+			expected: "DATABASE_URL=\"postgresql://user:pa'ss@db:5432/app\"\nPORT=8080\n",
+		},
+		{
+			format:   "k8s-secret",
+			expected: "apiVersion: v1\nkind: Secret\nmetadata:\n    name: my-app\nstringData:\n    DATABASE_URL: postgresql://user:pa'ss@db:5432/app\n    PORT: \"8080\"\ntype: Opaque\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := formatExportedValues(variables, tt.format, "My App")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatExportedValuesMultiLineDockerEnv(t *testing.T) {
+	if _, err := formatExportedValues(exportedVariables("KEY", "line1\nline2"), "docker-env", ""); err == nil {
+		t.Fatal("expected an error for a multi-line value")
+	}
+}
+
+func TestFormatExportedValuesJson(t *testing.T) {
+	got, err := formatExportedValues(exportedVariables("PORT", "8080", "DATABASE_URL", "postgresql://db:5432/app"), "json", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var variables []map[string]interface{}
+	if err := json.Unmarshal([]byte(got), &variables); err != nil {
+		t.Fatal(err)
+	}
+	if len(variables) != 2 || variables[0]["key"] != "DATABASE_URL" || variables[0]["value"] != "postgresql://db:5432/app" ||
+		variables[1]["key"] != "PORT" || variables[1]["value"] != "8080" {
+		t.Fatalf("unexpected json output %s", got)
+	}
+}
+
+func TestGetExportedVariablesSkipsSecrets(t *testing.T) {
+	variables := exportedVariables("PORT", "8080", "DB_PASSWORD", "prod/db")
+	accessId := "access-1"
+	variables[1].SecretManagerAccessId = &accessId
+	variables = append(variables, utils.EnvVarLineOutput{Key: "API_KEY", Scope: "APPLICATION", IsSecret: true})
+
+	for _, resolve := range []bool{false, true} {
+		exported, skipped := getExportedVariables(variables, "service", resolve)
+		if len(exported) != 1 || exported[0].Key != "PORT" || *exported[0].Value != "8080" {
+			t.Fatalf("resolve=%v: expected only PORT to be exported, got %+v", resolve, exported)
+		}
+		if !reflect.DeepEqual(skipped, []string{"API_KEY", "DB_PASSWORD"}) {
+			t.Fatalf("resolve=%v: unexpected skipped secrets %v", resolve, skipped)
+		}
+	}
+	if variables[1].Value == nil || *variables[1].Value != "prod/db" {
+		t.Fatal("the variables given must not be modified")
+	}
+}
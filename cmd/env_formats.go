@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// variablesFormats are the formats environment variables can be parsed from
+var variablesFormats = []string{"dotenv", "heroku-json", "compose", "kubernetes", "vercel", "render", "fly", "railway", "tfvars"}
+
+// variablesParseOptions are the options some formats need to be parsed.
+type variablesParseOptions struct {
+	// BaseDir is the directory the env_file of a Docker Compose file are relative to
+	BaseDir string
+	// ComposeService is the Docker Compose service to read the variables of, required when several services define variables
+	ComposeService string
+}
+
+// parseVariables reads the environment variables of content written in format.
+func parseVariables(content []byte, format string, options variablesParseOptions) (map[string]string, error) {
+	switch format {
+	case "dotenv":
+		return godotenv.UnmarshalBytes(content)
+	case "heroku-json", "railway":
+		// `heroku config --json` and `railway variables --json` return a flat object
+		return parseFlatJsonVariables(content)
+	case "fly":
+		// `fly config show` returns the whole app configuration, with the variables under env
+		var config struct {
+			Env map[string]interface{} `json:"env"`
+		}
+		if err := json.Unmarshal(content, &config); err != nil {
+			return nil, err
+		}
+		if config.Env == nil {
+			return parseFlatJsonVariables(content)
+		}
+		return stringifyValues(config.Env)
+	case "vercel":
+		return parseKeyValueJsonVariables(content, "envs")
+	case "render":
+		return parseKeyValueJsonVariables(content, "envVars")
+	case "compose":
+		return parseComposeVariables(content, options)
+	case "kubernetes":
+		return parseKubernetesVariables(content)
+	case "tfvars":
+		return parseTfvarsVariables(content)
+	default:
+		return nil, fmt.Errorf("unknown format %s, must be one of %s", format, strings.Join(variablesFormats, ", "))
+	}
+}
+
+func parseFlatJsonVariables(content []byte) (map[string]string, error) {
+	values := make(map[string]interface{})
+	if err := json.Unmarshal(content, &values); err != nil {
+		return nil, err
+	}
+	return stringifyValues(values)
+}
+
+// parseKeyValueJsonVariables reads a list of {"key": ..., "value": ...} objects, at the root or under listField.
+// The Render API nests each of them in an envVar object.
+func parseKeyValueJsonVariables(content []byte, listField string) (map[string]string, error) {
+	type keyValue struct {
+		Key    string  `json:"key"`
+		Value  *string `json:"value"`
+		EnvVar *struct {
+			Key   string  `json:"key"`
+			Value *string `json:"value"`
+		} `json:"envVar"`
+	}
+
+	var entries []keyValue
+	if err := json.Unmarshal(content, &entries); err != nil {
+		wrapper := make(map[string][]keyValue)
+		if errWrapper := json.Unmarshal(content, &wrapper); errWrapper != nil {
+			return nil, err
+		}
+		entries = wrapper[listField]
+	}
+
+	values := make(map[string]string)
+	for _, entry := range entries {
+		key, value := entry.Key, entry.Value
+		if entry.EnvVar != nil {
+			key, value = entry.EnvVar.Key, entry.EnvVar.Value
+		}
+		if key == "" {
+			continue
+		}
+		if value == nil {
+			// sensitive values are not exported
+			return nil, fmt.Errorf("the value of %s is missing from the export, decrypt it before parsing", key)
+		}
+		values[key] = *value
+	}
+
+	return values, nil
+}
+
+// composeEnvFile is an env_file entry of a Docker Compose service.
+type composeEnvFile struct {
+	Path     string
+	Required bool
+}
+
+func parseComposeVariables(content []byte, options variablesParseOptions) (map[string]string, error) {
+	var compose struct {
+		Services map[string]struct {
+			Environment yaml.Node `yaml:"environment"`
+			EnvFile     yaml.Node `yaml:"env_file"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(content, &compose); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name, service := range compose.Services {
+		if options.ComposeService == name || (options.ComposeService == "" && (!service.Environment.IsZero() || !service.EnvFile.IsZero())) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	switch {
+	case len(names) == 0 && options.ComposeService != "":
+		return nil, fmt.Errorf("service %s not found in the compose file", options.ComposeService)
+	case len(names) == 0:
+		return map[string]string{}, nil
+	case len(names) > 1:
+		return nil, fmt.Errorf("several services define variables (%s), select one with --compose-service", strings.Join(names, ", "))
+	}
+	service := compose.Services[names[0]]
+
+	values := make(map[string]string)
+
+	// env_file is a single path or a list of paths or {path, required} objects, and is overridden by environment
+	var envFiles []composeEnvFile
+	switch service.EnvFile.Kind {
+	case 0:
+	case yaml.ScalarNode:
+		envFiles = append(envFiles, composeEnvFile{Path: service.EnvFile.Value, Required: true})
+	case yaml.SequenceNode:
+		for _, item := range service.EnvFile.Content {
+			if item.Kind == yaml.ScalarNode {
+				envFiles = append(envFiles, composeEnvFile{Path: item.Value, Required: true})
+				continue
+			}
+			var envFile struct {
+				Path     string `yaml:"path"`
+				Required *bool  `yaml:"required"`
+			}
+			if err := item.Decode(&envFile); err != nil {
+				return nil, err
+			}
+			envFiles = append(envFiles, composeEnvFile{Path: envFile.Path, Required: envFile.Required == nil || *envFile.Required})
+		}
+	default:
+		return nil, errors.New("env_file must be a path or a list of paths")
+	}
+
+	for _, envFile := range envFiles {
+		path := envFile.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(options.BaseDir, path)
+		}
+		fileValues, err := godotenv.Read(path)
+		if err != nil {
+			if !envFile.Required {
+				continue
+			}
+			return nil, err
+		}
+		for key, value := range fileValues {
+			values[key] = value
+		}
+	}
+
+	// environment is a map, or a list of KEY=VALUE; variables without a value are taken from the shell and are skipped
+	switch service.Environment.Kind {
+	case 0:
+	case yaml.MappingNode:
+		environment := make(map[string]interface{})
+		if err := service.Environment.Decode(&environment); err != nil {
+			return nil, err
+		}
+		for key, value := range environment {
+			if value == nil {
+				continue
+			}
+			values[key] = fmt.Sprint(value)
+		}
+	case yaml.SequenceNode:
+		var environment []string
+		if err := service.Environment.Decode(&environment); err != nil {
+			return nil, err
+		}
+		for _, entry := range environment {
+			if key, value, ok := strings.Cut(entry, "="); ok {
+				values[key] = value
+			}
+		}
+	default:
+		return nil, errors.New("environment must be a map or a list")
+	}
+
+	return values, nil
+}
+
+// kubernetesManifest is the part of a ConfigMap, a Secret or a List of them holding variables.
+type kubernetesManifest struct {
+	Kind       string               `yaml:"kind"`
+	Data       map[string]string    `yaml:"data"`
+	StringData map[string]string    `yaml:"stringData"`
+	Items      []kubernetesManifest `yaml:"items"`
+}
+
+// parseKubernetesVariables reads the data of the ConfigMap and Secret manifests of content, which can hold several documents
+// or a List, as returned by kubectl get -o yaml.
+func parseKubernetesVariables(content []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var manifest kubernetesManifest
+		err := decoder.Decode(&manifest)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := addKubernetesManifestVariables(manifest, values); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func addKubernetesManifestVariables(manifest kubernetesManifest, values map[string]string) error {
+	switch manifest.Kind {
+	case "List":
+		for _, item := range manifest.Items {
+			if err := addKubernetesManifestVariables(item, values); err != nil {
+				return err
+			}
+		}
+	case "ConfigMap":
+		for key, value := range manifest.Data {
+			values[key] = value
+		}
+	case "Secret":
+		for key, value := range manifest.Data {
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return fmt.Errorf("the data %s of the secret is not base64 encoded: %w", key, err)
+			}
+			values[key] = string(decoded)
+		}
+		for key, value := range manifest.StringData {
+			values[key] = value
+		}
+	}
+
+	return nil
+}
+
+// parseTfvarsVariables reads the string, number and bool variables of a .tfvars file. Lists and maps are not supported.
+func parseTfvarsVariables(content []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected name = value", lineNumber)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, "<<"):
+			// heredoc: <<EOF or <<-EOF, ended by a line holding only the delimiter
+			delimiter := strings.TrimPrefix(strings.TrimPrefix(value, "<<"), "-")
+			var heredoc []string
+			closed := false
+			for scanner.Scan() {
+				lineNumber++
+				if strings.TrimSpace(scanner.Text()) == delimiter {
+					closed = true
+					break
+				}
+				heredoc = append(heredoc, scanner.Text())
+			}
+			if !closed {
+				return nil, fmt.Errorf("line %d: heredoc %s of %s is not closed", lineNumber, delimiter, key)
+			}
+			values[key] = strings.Join(heredoc, "\n") + "\n"
+		case strings.HasPrefix(value, `"`):
+			quoted, comment := cutTfvarsString(value)
+			unquoted, err := strconv.Unquote(quoted)
+			if err != nil || (comment != "" && !strings.HasPrefix(comment, "#") && !strings.HasPrefix(comment, "//")) {
+				return nil, fmt.Errorf("line %d: invalid string for %s", lineNumber, key)
+			}
+			values[key] = unquoted
+		case strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{"):
+			return nil, fmt.Errorf("line %d: %s is a list or a map, which can't be an environment variable", lineNumber, key)
+		default:
+			// numbers and bools, possibly followed by a comment
+			value, _, _ = strings.Cut(value, "#")
+			value, _, _ = strings.Cut(value, "//")
+			values[key] = strings.TrimSpace(value)
+		}
+	}
+
+	return values, scanner.Err()
+}
+
+// cutTfvarsString splits value, starting with a quoted string, after the closing quote of the string.
+// The rest is returned trimmed, it is a comment in a valid file.
+func cutTfvarsString(value string) (string, string) {
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '"':
+			return value[:i+1], strings.TrimSpace(value[i+1:])
+		}
+	}
+	return value, ""
+}
+
+// formatDotenvLine returns the KEY=value line of a .env file. The value is written as is when it is read back unchanged,
+// and double-quoted and escaped following the rules of godotenv otherwise, e.g. for multi-line values.
+func formatDotenvLine(key string, value string) (string, error) {
+	plain := true
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && !strings.ContainsRune("_-.,:/@+=%*?&~^", r) {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return key + "=" + value, nil
+	}
+	return godotenv.Marshal(map[string]string{key: value})
+}
+
+func stringifyValues(values map[string]interface{}) (map[string]string, error) {
+	result := make(map[string]string)
+	for key, value := range values {
+		switch value := value.(type) {
+		case nil:
+			result[key] = ""
+		case string:
+			result[key] = value
+		case float64, bool:
+			result[key] = fmt.Sprint(value)
+		default:
+			return nil, fmt.Errorf("the value of %s is not a string", key)
+		}
+	}
+	return result, nil
+}
+
+// readVariablesFile reads the environment variables of the file written in format, or of stdin if path is empty or "-".
+func readVariablesFile(path string, format string, composeService string) (map[string]string, error) {
+	var content []byte
+	var err error
+	options := variablesParseOptions{ComposeService: composeService}
+	if path == "" || path == "-" {
+		options.BaseDir = "."
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		options.BaseDir = filepath.Dir(path)
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parseVariables(content, format, options)
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/joho/godotenv"
+)
+
+func TestParseVariables(t *testing.T) {
+	tests := []struct {
+		format   string
+		content  string
+		expected map[string]string
+	}{
+		{
+			format:   "heroku-json",
+			content:  `{"DATABASE_URL": "postgres://db", "WORKERS": 4}`,
+			expected: map[string]string{"DATABASE_URL": "postgres://db", "WORKERS": "4"},
+		},
+		{
+			format:   "fly",
+			content:  `{"app": "my-app", "env": {"PORT": "8080", "DEBUG": false}}`,
+			expected: map[string]string{"PORT": "8080", "DEBUG": "false"},
+		},
+		{
+			format:   "vercel",
+			content:  `{"envs": [{"key": "API_URL", "value": "https://api", "type": "plain", "target": ["production"]}]}`,
+			expected: map[string]string{"API_URL": "https://api"},
+		},
+		{
+			format:   "render",
+			content:  `[{"envVar": {"key": "PORT", "value": "10000"}, "cursor": "abc"}]`,
+			expected: map[string]string{"PORT": "10000"},
+		},
+		{
+			format: "kubernetes",
+			content: `apiVersion: v1
+kind: List
+items:
+- kind: ConfigMap
+  data:
+    LOG_LEVEL: info
+- kind: Secret
+  data:
+    PASSWORD: c2VjcmV0
+---
+kind: Secret
+stringData:
+  TOKEN: abc
+`,
+			expected: map[string]string{"LOG_LEVEL": "info", "PASSWORD": "secret", "TOKEN": "abc"},
+		},
+		{
+			format: "tfvars",
+			content: `# comment
+region = "eu-west-3"
+name = "app # 1" # inline comment after a string
+url = "https://example.com" // inline comment
+instance_count = 2 # inline comment
+enabled = true
+certificate = <<EOT
+line1
+EOT
+`,
+			expected: map[string]string{"region": "eu-west-3", "name": "app # 1", "url": "https://example.com", "instance_count": "2", "enabled": "true", "certificate": "line1\n"},
+		},
+		{
+			format: "compose",
+			content: `services:
+  db:
+    image: postgres
+  api:
+    environment:
+      - PORT=8080
+      - FROM_SHELL
+`,
+			expected: map[string]string{"PORT": "8080"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := parseVariables([]byte(tt.content), tt.format, variablesParseOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseComposeVariablesEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "api.env"), []byte("PORT=3000\nSECRET=abc\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	content := `services:
+  api:
+    env_file:
+      - api.env
+      - path: missing.env
+        required: false
+    environment:
+      PORT: 8080
+  worker:
+    environment:
+      QUEUE: jobs
+`
+
+	if _, err := parseVariables([]byte(content), "compose", variablesParseOptions{BaseDir: dir}); err == nil {
+		t.Fatal("expected an error when several services define variables")
+	}
+
+	got, err := parseVariables([]byte(content), "compose", variablesParseOptions{BaseDir: dir, ComposeService: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"PORT": "8080", "SECRET": "abc"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestParseTfvarsVariablesInvalidString(t *testing.T) {
+	if _, err := parseVariables([]byte(`name = "value" trailing`), "tfvars", variablesParseOptions{}); err == nil {
+		t.Fatal("expected an error for a string followed by something else than a comment")
+	}
+}
+
+func TestFormatDotenvLine(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"8080", "KEY=8080"},
+		{"postgresql://user@db:5432/app?sslmode=require", "KEY=postgresql://user@db:5432/app?sslmode=require"},
+		{"", "KEY="},
+		{"007", "KEY=007"},
+		{"-----BEGIN KEY-----\nabc\n-----END KEY-----\n", `KEY="-----BEGIN KEY-----\nabc\n-----END KEY-----\n"`},
+		{`say "hi" $HOME # not a comment`, `KEY="say \"hi\" \$HOME # not a comment"`},
+		{" padded ", `KEY=" padded "`},
+	}
+
+	for _, tt := range tests {
+		line, err := formatDotenvLine("KEY", tt.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != tt.expected {
+			t.Errorf("formatDotenvLine(%q) = %s, expected %s", tt.value, line, tt.expected)
+		}
+
+		parsed, err := godotenv.Unmarshal(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed["KEY"] != tt.value {
+			t.Errorf("%s is read back as %q, expected %q", line, parsed["KEY"], tt.value)
+		}
+	}
+}
@@ -8,12 +8,14 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
-	"github.com/joho/godotenv"
 	"github.com/manifoldco/promptui"
 	"github.com/qovery/qovery-cli/utils"
 	"github.com/spf13/cobra"
 )
 
+var importFrom string
+var importComposeService string
+
 var envImportCmd = &cobra.Command{
 	Use:   "import",
 	Short: "Import environment variables/secrets for a Qovery app",
@@ -21,7 +23,10 @@ var envImportCmd = &cobra.Command{
 		utils.Capture(cmd)
 
 		dotEnvFilePath := ""
-		if len(args) == 0 {
+		if len(args) == 0 && importFrom != "dotenv" {
+			utils.PrintlnError(fmt.Errorf("the file to import is required with --from %s", importFrom))
+			return
+		} else if len(args) == 0 {
 			file, err := scanAndSelectDotEnvFile()
 			if err != nil {
 				utils.PrintlnError(err)
@@ -41,7 +46,7 @@ var envImportCmd = &cobra.Command{
 			return
 		}
 
-		envs, err := godotenv.Read(dotEnvFilePath)
+		envs, err := readVariablesFile(dotEnvFilePath, importFrom, importComposeService)
 		if err != nil {
 			utils.PrintlnError(err)
 			return
@@ -74,7 +79,7 @@ var envImportCmd = &cobra.Command{
 
 		isSecrets := envVarOrSecret == "Secrets"
 
-		envsToImport := getEnvsToImport(envs, utils.SortKeys)
+		envsToImport, secretKeys := getEnvsToImport(envs, utils.SortKeys, isSecrets)
 		if len(envsToImport) == 0 {
 			utils.PrintlnError(fmt.Errorf("no environment variables to import"))
 			return
@@ -98,11 +103,16 @@ var envImportCmd = &cobra.Command{
 
 		for k, v := range envsToImport {
 			var err error
+			isSecret := isSecrets || secretKeys[k]
 
 			// Use different API calls based on service type
 			if service.Type == utils.ContainerType {
-				if isSecrets {
+				if isSecret {
 					if overrideEnvVarOrSecret {
+						// a key converted to a secret may exist as a variable from a previous import
+						if secretKeys[k] {
+							_ = utils.DeleteContainerEnvironmentVariable(service.ID, k)
+						}
 						_ = utils.DeleteContainerSecret(service.ID, k)
 					}
 					err = utils.AddContainerSecret(service.ID, k, v)
@@ -114,8 +124,12 @@ var envImportCmd = &cobra.Command{
 				}
 			} else {
 				// ApplicationType
-				if isSecrets {
+				if isSecret {
 					if overrideEnvVarOrSecret {
+						// a key converted to a secret may exist as a variable from a previous import
+						if secretKeys[k] {
+							_ = utils.DeleteEnvironmentVariable(service.ID, k)
+						}
 						_ = utils.DeleteSecret(service.ID, k)
 					}
 					err = utils.AddSecret(service.ID, k, v)
@@ -177,7 +191,9 @@ func scanAndSelectDotEnvFile() (string, error) {
 	return result, nil
 }
 
-func getEnvsToImport(envs map[string]string, sortKeys bool) map[string]string {
+// getEnvsToImport prompts for the variables to import. When they are not imported as secrets, the ones looking like secrets
+// are flagged and the keys to import as secrets instead are returned as well.
+func getEnvsToImport(envs map[string]string, sortKeys bool, isSecrets bool) (map[string]string, map[string]bool) {
 	var envKeys []string
 
 	if sortKeys {
@@ -206,7 +222,7 @@ func getEnvsToImport(envs map[string]string, sortKeys bool) map[string]string {
 	var selectedEnvKeys []string
 	err := survey.AskOne(prompt, &selectedEnvKeys)
 	if err != nil {
-		return nil
+		return nil, nil
 	}
 
 	results := make(map[string]string)
@@ -215,10 +231,16 @@ func getEnvsToImport(envs map[string]string, sortKeys bool) map[string]string {
 		results[sKey[0]] = envs[sKey[0]]
 	}
 
-	return results
+	if isSecrets {
+		return results, nil
+	}
+
+	return results, proposeSecrets(results)
 }
 
 func init() {
 	envCmd.AddCommand(envImportCmd)
 	envImportCmd.Flags().BoolVarP(&utils.SortKeys, "sort", "", false, "Sort environment variables by key")
+	envImportCmd.Flags().StringVarP(&importFrom, "from", "", "dotenv", "Format of the file to import: "+strings.Join(variablesFormats, ", "))
+	envImportCmd.Flags().StringVarP(&importComposeService, "compose-service", "", "", "Docker Compose service to import, required when several services define variables")
 }
@@ -1,18 +1,32 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/qovery/qovery-cli/utils"
 	"github.com/spf13/cobra"
-	"os"
+	"sort"
+	"strings"
 )
 
 var parseHerokuJson bool
+var parseFrom string
+var parseComposeService string
 
 var envParseCmd = &cobra.Command{
-	Use:   "parse",
+	Use:   "parse [file]",
 	Short: "Parse environment variables and create .env (dot env) file",
+	Long: `Parse environment variables exported from another platform and print them as a .env (dot env) file, which can be imported with 'qovery env import'.
+The file is read from stdin when not specified. Supported formats:
+- heroku-json: output of 'heroku config -a <app> --json'
+- compose: environment and env_file of a Docker Compose service
+- kubernetes: data of ConfigMap and Secret manifests
+- vercel: JSON export of the Vercel API (the "envs" list)
+- render: JSON export of the Render API (the env vars list)
+- fly: output of 'fly config show', or a JSON object of the variables
+- railway: output of 'railway variables --json'
+- tfvars: string, number and bool variables of a Terraform .tfvars file
+The variables are printed as KEY=value lines, sorted by key. Values which would not be read back unchanged, such as
+multi-line values, are double-quoted and escaped.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Capture(cmd)
 
@@ -21,53 +35,52 @@ var envParseCmd = &cobra.Command{
 			return
 		}
 
-		if !parseHerokuJson {
-			utils.PrintlnError(fmt.Errorf("you need to specify an option like '--heroku-json' or other. Type -h to see different options"))
-			return
-		}
-
-		dotEnvFilePath := ""
-		if len(args) == 0 && !parseHerokuJson {
-			file, err := scanAndSelectDotEnvFile()
-			if err != nil {
-				utils.PrintlnError(err)
-				return
-			}
-
-			dotEnvFilePath = file
-		} else if len(args) == 1 && !parseHerokuJson {
-			dotEnvFilePath = args[0]
-		} else if !parseHerokuJson {
-			utils.PrintlnError(fmt.Errorf("more than one arg is not allowed"))
-			return
+		if parseHerokuJson {
+			parseFrom = "heroku-json"
 		}
 
-		if dotEnvFilePath == "" && !parseHerokuJson {
-			utils.PrintlnError(fmt.Errorf("no dot env file specified"))
+		if parseFrom == "" {
+			utils.PrintlnError(fmt.Errorf("you need to specify the format with '--from' (%s). Type -h to see different options", strings.Join(variablesFormats, ", ")))
 			return
 		}
 
-		var envs map[string]string
-		var err error
-
-		if parseHerokuJson {
-			envs = make(map[string]string)
-			err = json.NewDecoder(os.Stdin).Decode(&envs)
+		file := ""
+		if len(args) == 1 {
+			file = args[0]
 		}
 
+		envs, err := readVariablesFile(file, parseFrom, parseComposeService)
 		if err != nil {
 			utils.PrintlnError(err)
-			utils.Println("Did you execute 'heroku config -a <your_heroku_app_name> --json' command?")
+			if parseFrom == "heroku-json" {
+				utils.Println("Did you execute 'heroku config -a <your_heroku_app_name> --json' command?")
+			}
 			return
 		}
 
-		for key, value := range envs {
-			fmt.Printf("%s=%s\n", key, value)
+		keys := make([]string, 0, len(envs))
+		for key := range envs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			line, err := formatDotenvLine(key, envs[key])
+			if err != nil {
+				utils.PrintlnError(err)
+				return
+			}
+			fmt.Println(line)
 		}
 	},
 }
 
 func init() {
 	envCmd.AddCommand(envParseCmd)
-	envParseCmd.Flags().BoolVarP(&parseHerokuJson, "heroku-json", "j", false, "Parse environment variables from a Heroku JSON payload")
+	envParseCmd.Flags().BoolVarP(&parseHerokuJson, "heroku-json", "j", false, "Parse environment variables from a Heroku JSON payload, same as --from heroku-json")
+	envParseCmd.Flags().StringVarP(&parseFrom, "from", "", "", "Format to parse: "+strings.Join(variablesFormats, ", "))
+	envParseCmd.Flags().StringVarP(&parseComposeService, "compose-service", "", "", "Docker Compose service to parse, required when several services define variables")
+	envParseCmd.Example = "heroku config -a <app> --json | qovery env parse --heroku-json > .env\n" +
+		"qovery env parse --from compose --compose-service api docker-compose.yml > .env\n" +
+		"kubectl get configmap,secret -o yaml | qovery env parse --from kubernetes > .env"
 }
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+// interpolationPattern matches the {{KEY}} references of a value
+var interpolationPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*}}`)
+
+// variableProvenance is an effective variable of a service, with where its value comes from.
+type variableProvenance struct {
+	Key      string  `json:"key"`
+	Value    *string `json:"value"`
+	IsSecret bool    `json:"is_secret"`
+	Scope    string  `json:"scope"`
+	Source   string  `json:"source"`
+	// Shadows lists the scopes of the variables with the same key hidden by this one
+	Shadows  []string `json:"shadows,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+var envResolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Show the effective environment variables of a service and where their value comes from",
+	Long: `Show the effective environment variables of a service and where their value comes from: built-in, project, environment or service variable,
+alias, override or external secret. Variables hiding a variable with the same key of another scope, dangling aliases and references to unknown variables are flagged.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		organizationId, _, environmentId, err := getOrganizationProjectEnvironmentContextResourcesIds(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		service, err := getServiceContextResourceId(client, serviceName, environmentId)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		if service == nil {
+			utils.PrintlnError(fmt.Errorf("service %s not found", serviceName))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		variablesResponse, err := utils.ListServiceVariables(client, string(service.ID), service.Type)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		var variables []utils.EnvVarLineOutput
+		hasExternalSecrets := false
+		for _, variable := range variablesResponse {
+			line := utils.FromEnvironmentVariableToEnvVarLineOutput(variable)
+			hasExternalSecrets = hasExternalSecrets || line.SecretManagerAccessId != nil
+			variables = append(variables, line)
+		}
+
+		secretManagerNames := map[string]string{}
+		if hasExternalSecrets {
+			secretManagerNames, err = getSecretManagerAccessNamesById(client, organizationId, environmentId)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+		}
+
+		provenances := getVariablesProvenance(variables, secretManagerNames)
+
+		if jsonFlag {
+			if !utils.ShowValues {
+				for i := range provenances {
+					provenances[i].Value = nil
+				}
+			}
+			j, err := json.Marshal(provenances)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			utils.Println(string(j))
+			return
+		}
+
+		var data [][]string
+		for _, provenance := range provenances {
+			value := "********"
+			if utils.ShowValues && provenance.Value != nil && !provenance.IsSecret {
+				value = *provenance.Value
+			}
+			data = append(data, []string{provenance.Key, value, provenance.Source, strings.Join(provenance.Shadows, ", "), strings.Join(provenance.Warnings, "; ")})
+		}
+
+		err = utils.PrintTable([]string{"Key", "Value", "Source", "Shadows", "Warnings"}, data)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	},
+}
+
+// getVariablesProvenance walks the scopes and the alias and override links of the variables of a service,
+// the way they are resolved by Qovery, and returns the effective variables sorted by key.
+func getVariablesProvenance(variables []utils.EnvVarLineOutput, secretManagerNames map[string]string) []variableProvenance {
+	effectiveVariables := utils.EffectiveVariables(variables)
+	resolved := utils.ResolveVariables(variables)
+
+	effectiveByKey := make(map[string]utils.EnvVarLineOutput)
+	for _, variable := range effectiveVariables {
+		effectiveByKey[variable.Key] = variable
+	}
+
+	var provenances []variableProvenance
+	for _, variable := range effectiveVariables {
+		provenance := variableProvenance{
+			Key:      variable.Key,
+			Value:    resolved[variable.Key],
+			IsSecret: variable.IsSecret,
+			Scope:    variable.Scope,
+			Source:   getScopeSource(variable.Scope),
+		}
+
+		switch {
+		case variable.AliasParentKey != nil:
+			parent, ok := effectiveByKey[*variable.AliasParentKey]
+			if ok {
+				provenance.Source = fmt.Sprintf("alias of %s (%s)", parent.Key, getScopeSource(parent.Scope))
+				provenance.IsSecret = provenance.IsSecret || parent.IsSecret
+			} else {
+				provenance.Source = fmt.Sprintf("alias of %s", *variable.AliasParentKey)
+				provenance.Warnings = append(provenance.Warnings, fmt.Sprintf("dangling alias: %s does not exist", *variable.AliasParentKey))
+			}
+		case variable.OverrideParentKey != nil:
+			provenance.Source = fmt.Sprintf("override of %s", *variable.OverrideParentKey)
+		case variable.SecretManagerAccessId != nil:
+			name, ok := secretManagerNames[*variable.SecretManagerAccessId]
+			if !ok {
+				name = *variable.SecretManagerAccessId
+			}
+			provenance.Source = fmt.Sprintf("external secret from %s", name)
+		}
+
+		for _, other := range variables {
+			if other.Key != variable.Key || other.Id == variable.Id {
+				continue
+			}
+			provenance.Shadows = append(provenance.Shadows, getScopeSource(other.Scope))
+			if variable.OverrideParentKey != nil && *variable.OverrideParentKey == other.Key {
+				provenance.Source = fmt.Sprintf("override of %s (%s)", other.Key, getScopeSource(other.Scope))
+			}
+		}
+		if len(provenance.Shadows) > 0 && variable.OverrideParentKey == nil {
+			provenance.Warnings = append(provenance.Warnings, "shadows a variable of another scope without overriding it")
+		}
+
+		if variable.AliasParentKey == nil && variable.Value != nil {
+			for _, match := range interpolationPattern.FindAllStringSubmatch(*variable.Value, -1) {
+				if _, ok := effectiveByKey[match[1]]; !ok {
+					provenance.Warnings = append(provenance.Warnings, fmt.Sprintf("references unknown variable %s", match[1]))
+				}
+			}
+		}
+
+		provenances = append(provenances, provenance)
+	}
+
+	return provenances
+}
+
+func getScopeSource(scope string) string {
+	switch qovery.APIVariableScopeEnum(scope) {
+	case qovery.APIVARIABLESCOPEENUM_BUILT_IN:
+		return "built-in"
+	case qovery.APIVARIABLESCOPEENUM_PROJECT:
+		return "project"
+	case qovery.APIVARIABLESCOPEENUM_ENVIRONMENT:
+		return "environment"
+	default:
+		return "service"
+	}
+}
+
+func init() {
+	envCmd.AddCommand(envResolveCmd)
+	envResolveCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	envResolveCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	envResolveCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+	envResolveCmd.Flags().StringVarP(&serviceName, "service", "", "", "Service Name")
+	envResolveCmd.Flags().BoolVarP(&utils.ShowValues, "show-values", "", false, "Show env var values")
+	envResolveCmd.Flags().BoolVarP(&jsonFlag, "json", "", false, "JSON output")
+	envResolveCmd.Example = "qovery env resolve --service <service_name>\n" +
+		"qovery env resolve --service <service_name> --show-values --json"
+}
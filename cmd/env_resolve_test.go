@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+func TestGetVariablesProvenance(t *testing.T) {
+	value := func(v string) *string { return &v }
+	databaseUrl := "DATABASE_URL"
+	missing := "MISSING"
+	logLevel := "LOG_LEVEL"
+	managerId := "manager-id"
+
+	variables := []utils.EnvVarLineOutput{
+		{Id: "1", Key: "DATABASE_URL", Value: value("postgresql://db"), Scope: "BUILT_IN"},
+		{Id: "2", Key: "DB", Value: value("DATABASE_URL"), Scope: "APPLICATION", AliasParentKey: &databaseUrl},
+		{Id: "3", Key: "BROKEN", Value: value("MISSING"), Scope: "APPLICATION", AliasParentKey: &missing},
+		{Id: "4", Key: "LOG_LEVEL", Value: value("info"), Scope: "PROJECT"},
+		{Id: "5", Key: "LOG_LEVEL", Value: value("debug"), Scope: "APPLICATION", OverrideParentKey: &logLevel},
+		{Id: "6", Key: "REGION", Value: value("eu"), Scope: "PROJECT"},
+		{Id: "7", Key: "REGION", Value: value("us"), Scope: "ENVIRONMENT"},
+		{Id: "8", Key: "URL", Value: value("https://{{HOST}}/{{PATH}}"), Scope: "ENVIRONMENT"},
+		{Id: "9", Key: "HOST", Value: value("example.com"), Scope: "ENVIRONMENT"},
+		{Id: "10", Key: "API_KEY", Value: value("path/to/key"), Scope: "APPLICATION", SecretManagerAccessId: &managerId},
+	}
+
+	provenances := getVariablesProvenance(variables, map[string]string{managerId: "aws-secrets"})
+
+	expected := map[string]variableProvenance{
+		"API_KEY":      {Source: "external secret from aws-secrets"},
+		"BROKEN":       {Source: "alias of MISSING", Warnings: []string{"dangling alias: MISSING does not exist"}},
+		"DATABASE_URL": {Source: "built-in"},
+		"DB":           {Source: "alias of DATABASE_URL (built-in)"},
+		"HOST":         {Source: "environment"},
+		"LOG_LEVEL":    {Source: "override of LOG_LEVEL (project)", Shadows: []string{"project"}},
+		"REGION":       {Source: "environment", Shadows: []string{"project"}, Warnings: []string{"shadows a variable of another scope without overriding it"}},
+		"URL":          {Source: "environment", Warnings: []string{"references unknown variable PATH"}},
+	}
+
+	if len(provenances) != len(expected) {
+		t.Fatalf("expected %d variables, got %d", len(expected), len(provenances))
+	}
+	for _, provenance := range provenances {
+		want, ok := expected[provenance.Key]
+		if !ok {
+			t.Fatalf("unexpected variable %s", provenance.Key)
+		}
+		if provenance.Source != want.Source || !reflect.DeepEqual(provenance.Shadows, want.Shadows) || !reflect.DeepEqual(provenance.Warnings, want.Warnings) {
+			t.Errorf("%s: expected %+v, got %+v", provenance.Key, want, provenance)
+		}
+	}
+
+	if value := provenances[3].Value; provenances[3].Key != "DB" || value == nil || *value != "postgresql://db" {
+		t.Errorf("expected DB to resolve to the value of DATABASE_URL, got %v", value)
+	}
+}
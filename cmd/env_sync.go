@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var (
+	envSyncFile        string
+	envSyncSecretsFile string
+	envSyncScope       string
+	envSyncPrune       bool
+	envSyncYes         bool
+)
+
+const (
+	variableSyncCreate = "create"
+	variableSyncUpdate = "update"
+	variableSyncDelete = "delete"
+)
+
+// variableSyncAction is a change to apply to make the variables of a scope match the files.
+type variableSyncAction struct {
+	Action string
+	// Id is the id of the variable to update or delete, so that applying the plan does not list the variables again
+	Id       string
+	Key      string
+	Value    string
+	IsSecret bool
+	// Recreate is set when the variable is turned into a secret or the other way around, which requires to delete it first
+	Recreate bool
+}
+
+// variableSyncTarget is the project, environment or service whose variables are synced.
+type variableSyncTarget struct {
+	Scope          string
+	OrganizationId string
+	ProjectId      string
+	EnvironmentId  string
+	Service        *utils.Service
+}
+
+var envSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync the environment variables of a project, an environment or a service with dotenv files",
+	Long: `Sync the environment variables of a project, an environment or a service with dotenv files.
+The variables of --file and the secrets of --secrets-file are compared to the ones defined at the scope, and the resulting plan is shown before being applied.
+Variables missing from the files are only deleted with --prune. Aliases, overrides, external secrets and built-in variables are never modified.
+The values of secrets are not returned by the API: the secrets of --secrets-file are always updated.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if envSyncFile == "" && envSyncSecretsFile == "" {
+			utils.PrintlnError(errors.New("--file or --secrets-file is required"))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		variables, err := readDotEnvFileIfSet(envSyncFile)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		secrets, err := readDotEnvFileIfSet(envSyncSecretsFile)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		target, err := getVariableSyncTarget(client, envSyncScope)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		existing, err := target.listVariables(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		plan, err := computeVariableSyncPlan(existing, envSyncScope, variables, secrets, envSyncPrune)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if len(plan) == 0 {
+			utils.Println("Variables are already in sync, nothing to do")
+			return
+		}
+
+		printVariableSyncPlan(plan)
+
+		if !envSyncYes {
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				utils.PrintlnError(errors.New("--yes is required to apply the plan when not running in a terminal"))
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			if !utils.Validate("the sync") {
+				utils.Println("Sync aborted")
+				return
+			}
+		}
+
+		for _, action := range plan {
+			if err := target.apply(client, action); err != nil {
+				utils.PrintlnError(fmt.Errorf("cannot %s %s: %w", action.Action, action.Key, err))
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+		}
+
+		utils.Println(fmt.Sprintf("%d changes applied", len(plan)))
+	},
+}
+
+func readDotEnvFileIfSet(file string) (map[string]string, error) {
+	if file == "" {
+		return map[string]string{}, nil
+	}
+	return godotenv.Read(file)
+}
+
+func getVariableSyncTarget(client *qovery.APIClient, scope string) (*variableSyncTarget, error) {
+	if scope != "project" && scope != "environment" && scope != "service" {
+		return nil, errors.New("--scope must be 'project', 'environment' or 'service'")
+	}
+
+	organizationId, projectId, environmentId, err := getOrganizationProjectEnvironmentContextResourcesIds(client)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &variableSyncTarget{Scope: scope, OrganizationId: organizationId, ProjectId: projectId, EnvironmentId: environmentId}
+	if scope == "service" {
+		target.Service, err = getServiceContextResourceId(client, serviceName, environmentId)
+		if err != nil {
+			return nil, err
+		}
+		if target.Service == nil {
+			return nil, fmt.Errorf("service %s not found in organization %s", serviceName, organizationId)
+		}
+	}
+
+	return target, nil
+}
+
+func (target *variableSyncTarget) listVariableResponses(client *qovery.APIClient) ([]qovery.VariableResponse, error) {
+	switch target.Scope {
+	case "project":
+		return utils.ListProjectVariables(client, target.ProjectId)
+	case "environment":
+		return utils.ListEnvironmentVariables(client, target.EnvironmentId)
+	default:
+		return utils.ListServiceVariables(client, string(target.Service.ID), target.Service.Type)
+	}
+}
+
+func (target *variableSyncTarget) listVariables(client *qovery.APIClient) ([]utils.EnvVarLineOutput, error) {
+	variablesResponse, err := target.listVariableResponses(client)
+	if err != nil {
+		return nil, err
+	}
+
+	var variables []utils.EnvVarLineOutput
+	for _, variable := range variablesResponse {
+		variables = append(variables, utils.FromEnvironmentVariableToEnvVarLineOutput(variable))
+	}
+
+	return variables, nil
+}
+
+func (target *variableSyncTarget) apply(client *qovery.APIClient, action variableSyncAction) error {
+	if action.Action == variableSyncDelete || action.Recreate {
+		if err := target.delete(client, action.Id); err != nil {
+			return err
+		}
+		if action.Action == variableSyncDelete {
+			return nil
+		}
+		return target.create(client, action)
+	}
+
+	if action.Action == variableSyncCreate {
+		return target.create(client, action)
+	}
+
+	value := qovery.NullableString{}
+	value.Set(&action.Value)
+	variableEditRequest := qovery.VariableEditRequest{
+		Key:   action.Key,
+		Value: value,
+	}
+	_, _, err := client.VariableMainCallsAPI.EditVariable(context.Background(), action.Id).VariableEditRequest(variableEditRequest).Execute()
+	return err
+}
+
+func (target *variableSyncTarget) create(client *qovery.APIClient, action variableSyncAction) error {
+	switch target.Scope {
+	case "project":
+		return utils.CreateProjectVariable(client, target.ProjectId, action.Key, action.Value, action.IsSecret)
+	case "environment":
+		return utils.CreateEnvironmentVariable(client, target.ProjectId, target.EnvironmentId, action.Key, action.Value, action.IsSecret)
+	default:
+		scope, err := target.variableScope()
+		if err != nil {
+			return err
+		}
+		return utils.CreateServiceVariable(client, target.ProjectId, target.EnvironmentId, string(target.Service.ID), scope, action.Key, action.Value, action.IsSecret)
+	}
+}
+
+// variableScope returns the scope of the variables created on the target, as expected by the API.
+func (target *variableSyncTarget) variableScope() (string, error) {
+	switch target.Scope {
+	case "project":
+		return string(qovery.APIVARIABLESCOPEENUM_PROJECT), nil
+	case "environment":
+		return string(qovery.APIVARIABLESCOPEENUM_ENVIRONMENT), nil
+	default:
+		scope, err := utils.ServiceTypeToScope(target.Service.Type)
+		return string(scope), err
+	}
+}
+
+// delete deletes the variable by id: the target variables are listed once, not for each deleted key.
+func (target *variableSyncTarget) delete(client *qovery.APIClient, variableId string) error {
+	_, err := client.VariableMainCallsAPI.DeleteVariable(context.Background(), variableId).Execute()
+	return err
+}
+
+// computeVariableSyncPlan returns the changes to apply to the variables defined at the scope to match variables and secrets.
+func computeVariableSyncPlan(existing []utils.EnvVarLineOutput, scope string, variables map[string]string, secrets map[string]string, prune bool) ([]variableSyncAction, error) {
+	var duplicates []string
+	for key := range secrets {
+		if _, ok := variables[key]; ok {
+			duplicates = append(duplicates, key)
+		}
+	}
+	if len(duplicates) > 0 {
+		sort.Strings(duplicates)
+		return nil, fmt.Errorf("keys defined both as variable and as secret: %s", strings.Join(duplicates, ", "))
+	}
+
+	existingByKey := make(map[string]utils.EnvVarLineOutput)
+	var readOnly []string
+	for _, variable := range existing {
+		if !isVariableOfScope(variable, scope) {
+			continue
+		}
+		if variable.AliasParentKey != nil || variable.OverrideParentKey != nil || variable.SecretManagerAccessId != nil {
+			_, isVariable := variables[variable.Key]
+			_, isSecret := secrets[variable.Key]
+			if isVariable || isSecret {
+				readOnly = append(readOnly, variable.Key)
+			}
+			continue
+		}
+		existingByKey[variable.Key] = variable
+	}
+	if len(readOnly) > 0 {
+		sort.Strings(readOnly)
+		return nil, fmt.Errorf("keys defined as alias, override or external secret, which are not synced: %s", strings.Join(readOnly, ", "))
+	}
+
+	var plan []variableSyncAction
+	desired := func(values map[string]string, isSecret bool) {
+		for key, value := range values {
+			current, ok := existingByKey[key]
+			switch {
+			case !ok:
+				plan = append(plan, variableSyncAction{Action: variableSyncCreate, Key: key, Value: value, IsSecret: isSecret})
+			case current.IsSecret != isSecret:
+				plan = append(plan, variableSyncAction{Action: variableSyncUpdate, Id: current.Id, Key: key, Value: value, IsSecret: isSecret, Recreate: true})
+			case isSecret || current.Value == nil || *current.Value != value:
+				// the value of a secret is unknown, it is always updated
+				plan = append(plan, variableSyncAction{Action: variableSyncUpdate, Id: current.Id, Key: key, Value: value, IsSecret: isSecret})
+			}
+		}
+	}
+	desired(variables, false)
+	desired(secrets, true)
+
+	if prune {
+		for key, current := range existingByKey {
+			_, isVariable := variables[key]
+			_, isSecret := secrets[key]
+			if !isVariable && !isSecret {
+				plan = append(plan, variableSyncAction{Action: variableSyncDelete, Id: current.Id, Key: key, IsSecret: current.IsSecret})
+			}
+		}
+	}
+
+	sort.Slice(plan, func(i, j int) bool {
+		return plan[i].Key < plan[j].Key
+	})
+
+	return plan, nil
+}
+
+func printVariableSyncPlan(plan []variableSyncAction) {
+	counts := make(map[string]int)
+	var data [][]string
+	for _, action := range plan {
+		counts[action.Action]++
+
+		value := action.Value
+		if action.IsSecret {
+			value = "********"
+		}
+		if action.Action == variableSyncDelete {
+			value = ""
+		}
+
+		description := action.Action
+		if action.Recreate {
+			if action.IsSecret {
+				description += " (turned into a secret)"
+			} else {
+				description += " (turned into a variable)"
+			}
+		}
+
+		data = append(data, []string{description, action.Key, value})
+	}
+
+	_ = utils.PrintTable([]string{"Action", "Key", "Value"}, data)
+	utils.Println(fmt.Sprintf("%d to create, %d to update, %d to delete", counts[variableSyncCreate], counts[variableSyncUpdate], counts[variableSyncDelete]))
+}
+
+func init() {
+	envCmd.AddCommand(envSyncCmd)
+	envSyncCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	envSyncCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	envSyncCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+	envSyncCmd.Flags().StringVarP(&serviceName, "service", "", "", "Service Name")
+	envSyncCmd.Flags().StringVarP(&envSyncFile, "file", "", "", "dotenv file of the variables")
+	envSyncCmd.Flags().StringVarP(&envSyncSecretsFile, "secrets-file", "", "", "dotenv file of the secrets")
+	envSyncCmd.Flags().StringVarP(&envSyncScope, "scope", "", "service", "Scope of the variables to sync: project, environment or service")
+	envSyncCmd.Flags().BoolVarP(&envSyncPrune, "prune", "", false, "Delete the variables of the scope missing from the files")
+	envSyncCmd.Flags().BoolVarP(&envSyncYes, "yes", "y", false, "Apply the plan without confirmation")
+	envSyncCmd.Example = "qovery env sync --service <service_name> --file vars.env --secrets-file secrets.env\n" +
+		"qovery env sync --scope environment --file vars.env --prune --yes"
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+func TestComputeVariableSyncPlan(t *testing.T) {
+	value := func(v string) *string { return &v }
+	parent := "DATABASE_URL"
+	accessId := "access-1"
+	existing := []utils.EnvVarLineOutput{
+		{Id: "1", Key: "PORT", Value: value("8080"), Scope: "APPLICATION"},
+		{Id: "2", Key: "LOG_LEVEL", Value: value("info"), Scope: "APPLICATION"},
+		{Id: "3", Key: "API_KEY", Scope: "APPLICATION", IsSecret: true},
+		{Id: "4", Key: "TOKEN", Value: value("plain"), Scope: "APPLICATION"},
+		{Id: "5", Key: "OLD", Value: value("old"), Scope: "APPLICATION"},
+		{Key: "DB", Scope: "APPLICATION", AliasParentKey: &parent},
+		{Id: "6", Key: "DB_PASSWORD", Value: value("prod/db"), Scope: "APPLICATION", SecretManagerAccessId: &accessId},
+		{Key: "REGION", Value: value("eu"), Scope: "ENVIRONMENT"},
+		{Key: "QOVERY_ENV", Value: value("x"), Scope: "BUILT_IN"},
+	}
+	variables := map[string]string{"PORT": "8080", "LOG_LEVEL": "debug", "NEW": "new"}
+	secrets := map[string]string{"API_KEY": "secret", "TOKEN": "secret"}
+
+	tests := []struct {
+		name     string
+		prune    bool
+		expected []variableSyncAction
+	}{
+		{
+			name: "without prune",
+			expected: []variableSyncAction{
+				{Action: variableSyncUpdate, Id: "3", Key: "API_KEY", Value: "secret", IsSecret: true},
+				{Action: variableSyncUpdate, Id: "2", Key: "LOG_LEVEL", Value: "debug"},
+				{Action: variableSyncCreate, Key: "NEW", Value: "new"},
+				{Action: variableSyncUpdate, Id: "4", Key: "TOKEN", Value: "secret", IsSecret: true, Recreate: true},
+			},
+		},
+		{
+			name:  "with prune",
+			prune: true,
+			expected: []variableSyncAction{
+				{Action: variableSyncUpdate, Id: "3", Key: "API_KEY", Value: "secret", IsSecret: true},
+				{Action: variableSyncUpdate, Id: "2", Key: "LOG_LEVEL", Value: "debug"},
+				{Action: variableSyncCreate, Key: "NEW", Value: "new"},
+				{Action: variableSyncDelete, Id: "5", Key: "OLD"},
+				{Action: variableSyncUpdate, Id: "4", Key: "TOKEN", Value: "secret", IsSecret: true, Recreate: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := computeVariableSyncPlan(existing, "service", variables, secrets, tt.prune)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(plan, tt.expected) {
+				t.Fatalf("expected %+v, got %+v", tt.expected, plan)
+			}
+		})
+	}
+}
+
+func TestComputeVariableSyncPlanConflicts(t *testing.T) {
+	parent := "DATABASE_URL"
+	accessId := "access-1"
+	existing := []utils.EnvVarLineOutput{
+		{Key: "DB", Scope: "APPLICATION", AliasParentKey: &parent},
+		{Key: "DB_PASSWORD", Scope: "APPLICATION", SecretManagerAccessId: &accessId},
+	}
+
+	if _, err := computeVariableSyncPlan(nil, "service", map[string]string{"KEY": "a"}, map[string]string{"KEY": "b"}, false); err == nil {
+		t.Fatal("expected an error for a key defined as variable and secret")
+	}
+	if _, err := computeVariableSyncPlan(existing, "service", map[string]string{"DB": "a"}, nil, false); err == nil {
+		t.Fatal("expected an error for a key defined as alias")
+	}
+	if _, err := computeVariableSyncPlan(existing, "service", nil, map[string]string{"DB_PASSWORD": "a"}, false); err == nil {
+		t.Fatal("expected an error for a key defined as external secret")
+	}
+}
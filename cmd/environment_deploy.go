@@ -24,6 +24,9 @@ var environmentDeployCmd = &cobra.Command{
 
 		client := utils.GetQoveryClientPanicInCaseOfError()
 		envId := getEnvironmentIdFromContextPanicInCaseOfError(client)
+		if waitUntilUnlockedFlag {
+			waitUntilEnvironmentClusterUnlockedPanicInCaseOfError(client, envId)
+		}
 
 		if (servicesJson != "" || applicationNames != "" || containerNames != "" || lifecycleNames != "" ||
 			cronjobNames != "" || helmNames != "") && skipPausedServicesFlag {
@@ -394,5 +397,7 @@ func init() {
 	environmentDeployCmd.Flags().StringVarP(&cronjobNames, "cronjobs", "", "", "Cronjobs to deploy E.g. --cronjobs cronjob1:git_commit_id,cronjob2:git_commit_id). If you omit the git commit id, the same version will be used")
 	environmentDeployCmd.Flags().StringVarP(&helmNames, "helms", "", "", "Helms to deploy E.g. --helms helm1:chart_version|git_commit_id,helm2:chart_version|git_commit_id). If you omit the chart version or git commit id, the same version will be used")
 	environmentDeployCmd.Flags().BoolVarP(&watchFlag, "watch", "w", false, "Watch environment status until it's ready or an error occurs")
+	environmentDeployCmd.Flags().BoolVarP(&waitUntilUnlockedFlag, "wait-until-unlocked", "", false, "Wait for the environment cluster to be unlocked before deploying")
+	environmentDeployCmd.Flags().DurationVarP(&waitUntilUnlockedTimeout, "wait-until-unlocked-timeout", "", time.Hour, "Maximum time to wait for the cluster to be unlocked")
 	environmentDeployCmd.Flags().BoolVarP(&skipPausedServicesFlag, "skip-paused-services", "", false, "Skip paused services: paused services won't be started / deployed")
 }
@@ -41,6 +41,8 @@ var environmentEnvCreateCmd = &cobra.Command{
 			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
 		}
 
+		utils.IsSecret = proposeSecret(utils.Key, utils.Value, utils.IsSecret)
+
 		err = utils.CreateEnvironmentVariable(client, projectId, environment.Id, utils.Key, utils.Value, utils.IsSecret)
 		checkError(err)
 
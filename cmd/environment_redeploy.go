@@ -16,6 +16,9 @@ var environmentRedeployCmd = &cobra.Command{
 
 		client := utils.GetQoveryClientPanicInCaseOfError()
 		envId := getEnvironmentIdFromContextPanicInCaseOfError(client)
+		if waitUntilUnlockedFlag {
+			waitUntilEnvironmentClusterUnlockedPanicInCaseOfError(client, envId)
+		}
 		_, _, err := client.EnvironmentActionsAPI.
 			DeployEnvironment(context.Background(), envId).
 			Execute()
@@ -34,4 +37,6 @@ func init() {
 	environmentRedeployCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
 	environmentRedeployCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
 	environmentRedeployCmd.Flags().BoolVarP(&watchFlag, "watch", "w", false, "Watch environment status until it's ready or an error occurs")
+	environmentRedeployCmd.Flags().BoolVarP(&waitUntilUnlockedFlag, "wait-until-unlocked", "", false, "Wait for the environment cluster to be unlocked before deploying")
+	environmentRedeployCmd.Flags().DurationVarP(&waitUntilUnlockedTimeout, "wait-until-unlocked-timeout", "", time.Hour, "Maximum time to wait for the cluster to be unlocked")
 }
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var (
+	execAllPods bool
+	execTimeout time.Duration
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec -- <command> [args...]",
+	Short: "Run a command in a service container and return its exit code",
+	Long: `Run a command once in a service container, without tty and without reconnecting.
+The remote stdout and stderr are streamed to the local stdout and stderr and the exit code of the remote command is returned, which makes it usable from CI.
+
+Limitations:
+  - the command is wrapped in a shell script, so the container must provide sh, mktemp and sed
+  - stderr and the exit code are told apart from stdout with the \x1e and \x1f control characters, and the output is split
+    per line: binary output or output containing these characters is not reproduced faithfully`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if execAllPods && podName != "" {
+			utils.PrintlnError(errors.New("--all-pods and --pod can't be used together"))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		shellRequest, err := shellRequestWithContextFlags()
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		shellRequest.Command = args
+
+		ctx := context.Background()
+		if execTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, execTimeout)
+			defer cancel()
+		}
+
+		var exitCode int
+		if execAllPods {
+			exitCode, err = execOnAllPods(ctx, shellRequest)
+		} else {
+			exitCode, err = pkg.ExecCommand(ctx, shellRequest, os.Stdout, os.Stderr)
+		}
+		if err != nil {
+			utils.PrintlnError(err)
+			if exitCode == 0 {
+				exitCode = 1
+			}
+		}
+
+		os.Exit(exitCode)
+	},
+}
+
+// execOnAllPods runs the command on every pod of the service in parallel, prefixing the output by the pod name.
+// It returns the first non-zero exit code.
+func execOnAllPods(ctx context.Context, shellRequest *pkg.ShellRequest) (int, error) {
+	serviceType, err := getShellRequestServiceType(shellRequest)
+	if err != nil {
+		return 1, err
+	}
+
+	pods, err := pkg.ExecListPods(&pkg.PortForwardRequest{
+		ServiceID:      shellRequest.ServiceID,
+		ServiceType:    serviceType,
+		ProjectID:      shellRequest.ProjectID,
+		OrganizationID: shellRequest.OrganizationID,
+		EnvironmentID:  shellRequest.EnvironmentID,
+		ClusterID:      shellRequest.ClusterID,
+	})
+	if err != nil {
+		return 1, err
+	}
+	if len(pods.Pods) == 0 {
+		return 1, errors.New("no pod is running for this service")
+	}
+
+	var stdoutMutex, stderrMutex sync.Mutex
+	exitCodes := make([]int, len(pods.Pods))
+	errs := make([]error, len(pods.Pods))
+
+	var wg sync.WaitGroup
+	for i, pod := range pods.Pods {
+		podRequest := *shellRequest
+		podRequest.PodName = pod.Name
+		stdout := &pkg.PrefixWriter{Out: os.Stdout, Prefix: fmt.Sprintf("[%s] ", pod.Name), Mutex: &stdoutMutex}
+		stderr := &pkg.PrefixWriter{Out: os.Stderr, Prefix: fmt.Sprintf("[%s] ", pod.Name), Mutex: &stderrMutex}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			exitCodes[i], errs[i] = pkg.ExecCommand(ctx, &podRequest, stdout, stderr)
+			_ = stdout.Flush()
+			_ = stderr.Flush()
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("%s: %w", pod.Name, errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range pods.Pods {
+		if errs[i] != nil {
+			return max(exitCodes[i], 1), errs[i]
+		}
+		if exitCodes[i] != 0 {
+			return exitCodes[i], nil
+		}
+	}
+
+	return 0, nil
+}
+
+func getShellRequestServiceType(shellRequest *pkg.ShellRequest) (string, error) {
+	services, err := utils.GetEnvironmentServicesById(string(shellRequest.EnvironmentID))
+	if err != nil {
+		return "", err
+	}
+
+	for _, service := range services {
+		if service.ID == string(shellRequest.ServiceID) {
+			return strings.ToUpper(string(service.Type)), nil
+		}
+	}
+
+	return "", fmt.Errorf("service %s not found", shellRequest.ServiceID)
+}
+
+func init() {
+	execCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	execCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	execCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+	execCmd.Flags().StringVarP(&serviceName, "service", "", "", "Service Name")
+	execCmd.Flags().StringVarP(&podName, "pod", "p", "", "pod name where to exec into")
+	execCmd.Flags().StringVar(&podContainerName, "container", "", "container name inside the pod")
+	execCmd.Flags().BoolVar(&execAllPods, "all-pods", false, "run the command on every pod of the service, prefixing the output by the pod name")
+	execCmd.Flags().DurationVar(&execTimeout, "timeout", 0, "abort the command after this duration and exit with code 124 (e.g. '30s', '5m')")
+	execCmd.Example = "qovery exec --service <service_name> -- ./manage.py migrate\n" +
+		"qovery exec --organization <organization_name> --project <project_name> --environment <environment_name> --service <service_name> --timeout 30s -- curl -fsS localhost:8080/health\n" +
+		"qovery exec --service <service_name> --all-pods -- cat /etc/hostname"
+
+	rootCmd.AddCommand(execCmd)
+}
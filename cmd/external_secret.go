@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var externalSecretCmd = &cobra.Command{
+	Use:   "external-secret",
+	Short: "Manage the external secrets of a project, an environment and its services",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if len(args) == 0 {
+			_ = cmd.Help()
+			os.Exit(0)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(externalSecretCmd)
+}
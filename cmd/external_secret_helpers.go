@@ -3,10 +3,13 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/qovery/qovery-client-go"
 	"github.com/qovery/qovery-cli/pkg/cluster"
 	"github.com/qovery/qovery-cli/pkg/promptuifactory"
+	"github.com/qovery/qovery-cli/utils"
 )
 
 func getSecretManagerAccessIdByName(client *qovery.APIClient, organizationId, envId, name string) (string, error) {
@@ -38,3 +41,139 @@ func getSecretManagerAccessIdByName(client *qovery.APIClient, organizationId, en
 	}
 	return "", fmt.Errorf("secret manager access %q not found in cluster %s", name, matchedCluster.Name)
 }
+
+// getSecretManagerAccessNamesById returns the names of the secret manager accesses of the cluster of the environment, by id.
+func getSecretManagerAccessNamesById(client *qovery.APIClient, organizationId string, environmentId string) (map[string]string, error) {
+	env, _, err := client.EnvironmentMainCallsAPI.GetEnvironment(context.Background(), environmentId).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get environment: %w", err)
+	}
+
+	clusters, err := cluster.NewClusterService(client, &promptuifactory.PromptUiFactoryImpl{}).ListClusters(organizationId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	names := make(map[string]string)
+	for _, c := range clusters.GetResults() {
+		if c.Id != env.ClusterId {
+			continue
+		}
+		for _, access := range c.SecretManagerAccesses {
+			names[access.Id] = access.Name
+		}
+	}
+
+	return names, nil
+}
+
+// externalSecretReference is an external secret, with the secret manager and the path it references.
+type externalSecretReference struct {
+	Key                   string `json:"key"`
+	Scope                 string `json:"scope"`
+	Service               string `json:"service,omitempty"`
+	SecretManagerAccessId string `json:"secret_manager_access_id"`
+	// SecretManagerAccess is the name of the secret manager access, empty if it does not exist on the cluster
+	SecretManagerAccess string `json:"secret_manager_access"`
+	Reference           string `json:"reference"`
+}
+
+// listExternalSecrets lists the external secrets of the project, the environment and all its services.
+func listExternalSecrets(client *qovery.APIClient, organizationId string, environmentId string) ([]externalSecretReference, error) {
+	variables, err := listEnvironmentAndServicesVariables(client, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := getSecretManagerAccessNamesById(client, organizationId, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return getExternalSecretReferences(variables, names), nil
+}
+
+// listEnvironmentAndServicesVariables returns the variables of the environment and of each of its services,
+// the project and environment variables are listed again with every service.
+func listEnvironmentAndServicesVariables(client *qovery.APIClient, environmentId string) ([]utils.EnvVarLineOutput, error) {
+	variablesResponse, err := utils.ListEnvironmentVariables(client, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := utils.GetEnvironmentServicesById(environmentId)
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		if service.Type == utils.DatabaseType {
+			// databases don't have variables
+			continue
+		}
+		serviceVariables, err := utils.ListServiceVariables(client, service.ID, service.Type)
+		if err != nil {
+			return nil, err
+		}
+		variablesResponse = append(variablesResponse, serviceVariables...)
+	}
+
+	var variables []utils.EnvVarLineOutput
+	for _, variable := range variablesResponse {
+		variables = append(variables, utils.FromEnvironmentVariableToEnvVarLineOutput(variable))
+	}
+	return variables, nil
+}
+
+// getExternalSecretReferences keeps the external secrets of variables, once each as the project and environment ones
+// are listed with every service, sorted by scope and key.
+func getExternalSecretReferences(variables []utils.EnvVarLineOutput, secretManagerNames map[string]string) []externalSecretReference {
+	seen := make(map[string]bool)
+	var secrets []externalSecretReference
+	for _, variable := range variables {
+		if variable.SecretManagerAccessId == nil || seen[variable.Id] {
+			continue
+		}
+		seen[variable.Id] = true
+
+		secret := externalSecretReference{
+			Key:                   variable.Key,
+			Scope:                 variable.Scope,
+			SecretManagerAccessId: *variable.SecretManagerAccessId,
+			SecretManagerAccess:   secretManagerNames[*variable.SecretManagerAccessId],
+		}
+		if variable.Service != nil {
+			secret.Service = *variable.Service
+		}
+		if variable.Value != nil {
+			secret.Reference = *variable.Value
+		}
+		secrets = append(secrets, secret)
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].Scope != secrets[j].Scope {
+			return utils.ScopePriority(secrets[i].Scope) < utils.ScopePriority(secrets[j].Scope)
+		}
+		if secrets[i].Service != secrets[j].Service {
+			return secrets[i].Service < secrets[j].Service
+		}
+		return secrets[i].Key < secrets[j].Key
+	})
+
+	return secrets
+}
+
+// lintExternalSecretReference returns the problems of the external secret which would prevent its deployment and can be
+// found without reading the secret manager: the API does not resolve references, so a missing secret is not reported.
+func lintExternalSecretReference(secret externalSecretReference) []string {
+	var problems []string
+	if secret.SecretManagerAccess == "" {
+		problems = append(problems, fmt.Sprintf("secret manager access %s does not exist on the cluster", secret.SecretManagerAccessId))
+	}
+	if strings.TrimSpace(secret.Reference) == "" {
+		problems = append(problems, "empty reference")
+	} else if strings.TrimSpace(secret.Reference) != secret.Reference || strings.ContainsAny(secret.Reference, "\r\n") {
+		problems = append(problems, "the reference contains blank characters")
+	}
+	return problems
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var externalSecretLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check the external secret references of a project, an environment and its services are well-formed",
+	Long: `Check that every external secret of the project, the environment and its services references a secret manager access
+of the cluster of the environment, with a well-formed reference.
+
+This does not check that the referenced secrets exist: the API does not resolve references in the secret managers,
+so a reference to a missing secret is only reported when the service is deployed.
+The command exits with an error if an external secret reference is malformed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		organizationId, _, environmentId, err := getOrganizationProjectEnvironmentContextResourcesIds(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		secrets, err := listExternalSecrets(client, organizationId, environmentId)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		invalid := 0
+		var data [][]string
+		for _, secret := range secrets {
+			status := pterm.FgGreen.Sprint("Well-formed")
+			if problems := lintExternalSecretReference(secret); len(problems) > 0 {
+				invalid++
+				status = pterm.FgRed.Sprint(strings.Join(problems, "; "))
+			}
+			data = append(data, []string{secret.Key, secret.Scope, getExternalSecretServiceColumn(secret), getExternalSecretManagerColumn(secret), secret.Reference, status})
+		}
+
+		err = utils.PrintTable([]string{"Key", "Scope", "Service", "Secret Manager", "Reference", "Status"}, data)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if invalid > 0 {
+			utils.PrintlnError(fmt.Errorf("%d of %d external secret references are malformed", invalid, len(secrets)))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		utils.Println(fmt.Sprintf("%d external secret references are well-formed, their existence in the secret managers is not checked", len(secrets)))
+	},
+}
+
+func init() {
+	externalSecretCmd.AddCommand(externalSecretLintCmd)
+	externalSecretLintCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	externalSecretLintCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	externalSecretLintCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+}
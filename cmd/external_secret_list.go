@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var externalSecretListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the external secrets of a project, an environment and its services",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		organizationId, _, environmentId, err := getOrganizationProjectEnvironmentContextResourcesIds(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		secrets, err := listExternalSecrets(client, organizationId, environmentId)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if jsonFlag {
+			j, err := json.Marshal(secrets)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			utils.Println(string(j))
+			return
+		}
+
+		var data [][]string
+		for _, secret := range secrets {
+			data = append(data, []string{secret.Key, secret.Scope, getExternalSecretServiceColumn(secret), getExternalSecretManagerColumn(secret), secret.Reference})
+		}
+
+		err = utils.PrintTable([]string{"Key", "Scope", "Service", "Secret Manager", "Reference"}, data)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	},
+}
+
+func getExternalSecretServiceColumn(secret externalSecretReference) string {
+	if secret.Service == "" {
+		return "N/A"
+	}
+	return secret.Service
+}
+
+func getExternalSecretManagerColumn(secret externalSecretReference) string {
+	if secret.SecretManagerAccess == "" {
+		return secret.SecretManagerAccessId + " (not found)"
+	}
+	return secret.SecretManagerAccess
+}
+
+func init() {
+	externalSecretCmd.AddCommand(externalSecretListCmd)
+	externalSecretListCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	externalSecretListCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	externalSecretListCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+	externalSecretListCmd.Flags().BoolVarP(&jsonFlag, "json", "", false, "JSON output")
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var (
+	externalSecretMigrateScope           string
+	externalSecretMigrateKeys            []string
+	externalSecretMigrateReferencePrefix string
+	externalSecretMigrateMappingFile     string
+	externalSecretMigrateDryRun          bool
+	externalSecretMigrateYes             bool
+)
+
+// externalSecretMigration is a Qovery secret to replace by an external secret.
+type externalSecretMigration struct {
+	VariableId string
+	Key        string
+	Reference  string
+}
+
+var externalSecretMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Convert Qovery secrets into external secrets",
+	Long: `Convert the secrets of a project, an environment or a service into external secrets referencing a secret manager.
+The references are read from --mapping-file (a dotenv file of KEY=reference), or made of --reference-prefix followed by the key.
+Each secret is deleted then created again as an external secret with the same key and mount path: the values of the secrets
+are not returned by the API, make sure they are stored in the secret manager before migrating them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if externalSecretMigrateMappingFile == "" && externalSecretMigrateReferencePrefix == "" {
+			utils.PrintlnError(errors.New("--mapping-file or --reference-prefix is required"))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		mapping, err := readDotEnvFileIfSet(externalSecretMigrateMappingFile)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		target, err := getVariableSyncTarget(client, externalSecretMigrateScope)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		variablesResponse, err := target.listVariableResponses(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		var variables []utils.EnvVarLineOutput
+		mountPaths := make(map[string]string)
+		for _, variable := range variablesResponse {
+			variables = append(variables, utils.FromEnvironmentVariableToEnvVarLineOutput(variable))
+			mountPaths[variable.Id] = variable.GetMountPath()
+		}
+
+		migrations, err := getExternalSecretMigrations(variables, externalSecretMigrateScope, externalSecretMigrateKeys, mapping, externalSecretMigrateReferencePrefix)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		if len(migrations) == 0 {
+			utils.Println("No secret to migrate")
+			return
+		}
+
+		var data [][]string
+		for _, migration := range migrations {
+			data = append(data, []string{migration.Key, utils.SecretManagerAccessName, migration.Reference})
+		}
+		_ = utils.PrintTable([]string{"Key", "Secret Manager", "Reference"}, data)
+
+		if externalSecretMigrateDryRun {
+			return
+		}
+
+		secretManagerAccessId, err := getSecretManagerAccessIdByName(client, target.OrganizationId, target.EnvironmentId, utils.SecretManagerAccessName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		scope, err := target.variableScope()
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if !externalSecretMigrateYes {
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				utils.PrintlnError(errors.New("--yes is required to migrate the secrets when not running in a terminal"))
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			if !utils.Validate("the migration") {
+				utils.Println("Migration aborted")
+				return
+			}
+		}
+
+		serviceId := ""
+		if target.Service != nil {
+			serviceId = string(target.Service.ID)
+		}
+
+		for _, migration := range migrations {
+			if err := target.delete(client, migration.VariableId); err != nil {
+				utils.PrintlnError(fmt.Errorf("cannot delete the secret %s: %w", migration.Key, err))
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+
+			err := utils.CreateServiceExternalSecret(client, target.ProjectId, target.EnvironmentId, serviceId, scope, migration.Key, migration.Reference, secretManagerAccessId, mountPaths[migration.VariableId])
+			if err != nil {
+				utils.PrintlnError(fmt.Errorf("the secret %s has been deleted but the external secret can't be created, create it with the reference %s: %w", migration.Key, migration.Reference, err))
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+		}
+
+		utils.Println(fmt.Sprintf("%d secrets migrated to external secrets", len(migrations)))
+	},
+}
+
+// getExternalSecretMigrations returns the secrets defined at the scope to migrate, with their reference in the secret manager.
+// When mapping is set, only its keys are migrated, otherwise keys or all the secrets are, with prefix followed by the key as reference.
+func getExternalSecretMigrations(variables []utils.EnvVarLineOutput, scope string, keys []string, mapping map[string]string, prefix string) ([]externalSecretMigration, error) {
+	secrets := make(map[string]string)
+	for _, variable := range variables {
+		if isVariableOfScope(variable, scope) && variable.IsSecret && variable.AliasParentKey == nil && variable.OverrideParentKey == nil && variable.SecretManagerAccessId == nil {
+			secrets[variable.Key] = variable.Id
+		}
+	}
+
+	if len(mapping) > 0 {
+		keys = nil
+		for key := range mapping {
+			keys = append(keys, key)
+		}
+	} else if len(keys) == 0 {
+		for key := range secrets {
+			keys = append(keys, key)
+		}
+	}
+
+	var unknown []string
+	var migrations []externalSecretMigration
+	for _, key := range keys {
+		id, ok := secrets[key]
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+
+		reference, ok := mapping[key]
+		if !ok {
+			reference = prefix + key
+		}
+		migrations = append(migrations, externalSecretMigration{VariableId: id, Key: key, Reference: reference})
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("not secrets of the %s: %s", scope, strings.Join(unknown, ", "))
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Key < migrations[j].Key
+	})
+
+	return migrations, nil
+}
+
+func init() {
+	externalSecretCmd.AddCommand(externalSecretMigrateCmd)
+	externalSecretMigrateCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	externalSecretMigrateCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	externalSecretMigrateCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+	externalSecretMigrateCmd.Flags().StringVarP(&serviceName, "service", "", "", "Service Name")
+	externalSecretMigrateCmd.Flags().StringVarP(&externalSecretMigrateScope, "scope", "", "service", "Scope of the secrets to migrate: project, environment or service")
+	externalSecretMigrateCmd.Flags().StringSliceVarP(&externalSecretMigrateKeys, "key", "k", nil, "Key of a secret to migrate, all the secrets of the scope by default")
+	externalSecretMigrateCmd.Flags().StringVarP(&externalSecretMigrateReferencePrefix, "reference-prefix", "", "", "Reference of the secrets in the secret manager, followed by their key")
+	externalSecretMigrateCmd.Flags().StringVarP(&externalSecretMigrateMappingFile, "mapping-file", "", "", "dotenv file of the secrets to migrate and their reference in the secret manager")
+	externalSecretMigrateCmd.Flags().StringVarP(&utils.SecretManagerAccessName, "secret-manager-access-name", "", "", "Secret manager access name")
+	externalSecretMigrateCmd.Flags().BoolVarP(&externalSecretMigrateDryRun, "dry-run", "", false, "Only show the secrets to migrate")
+	externalSecretMigrateCmd.Flags().BoolVarP(&externalSecretMigrateYes, "yes", "y", false, "Migrate without confirmation")
+	externalSecretMigrateCmd.Example = "qovery external-secret migrate --service <service_name> --secret-manager-access-name aws --reference-prefix my-app/prod/ --dry-run\n" +
+		"qovery external-secret migrate --scope environment --secret-manager-access-name aws --mapping-file references.env --yes"
+
+	_ = externalSecretMigrateCmd.MarkFlagRequired("secret-manager-access-name")
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+func TestGetExternalSecretReferences(t *testing.T) {
+	value := func(v string) *string { return &v }
+	aws := "aws-id"
+	removed := "removed-id"
+	api := "api"
+
+	variables := []utils.EnvVarLineOutput{
+		{Id: "1", Key: "API_KEY", Value: value("prod/api-key"), Scope: "APPLICATION", Service: &api, SecretManagerAccessId: &aws},
+		{Id: "2", Key: "DB_PASSWORD", Value: value("prod/db"), Scope: "ENVIRONMENT", SecretManagerAccessId: &aws},
+		// environment external secrets are listed again with each service
+		{Id: "2", Key: "DB_PASSWORD", Value: value("prod/db"), Scope: "ENVIRONMENT", SecretManagerAccessId: &aws},
+		{Id: "3", Key: "TOKEN", Value: value(""), Scope: "PROJECT", SecretManagerAccessId: &removed},
+		{Id: "4", Key: "PORT", Value: value("8080"), Scope: "APPLICATION"},
+	}
+
+	secrets := getExternalSecretReferences(variables, map[string]string{aws: "aws"})
+
+	expected := []externalSecretReference{
+		{Key: "TOKEN", Scope: "PROJECT", SecretManagerAccessId: removed},
+		{Key: "DB_PASSWORD", Scope: "ENVIRONMENT", SecretManagerAccessId: aws, SecretManagerAccess: "aws", Reference: "prod/db"},
+		{Key: "API_KEY", Scope: "APPLICATION", Service: "api", SecretManagerAccessId: aws, SecretManagerAccess: "aws", Reference: "prod/api-key"},
+	}
+	if !reflect.DeepEqual(secrets, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, secrets)
+	}
+
+	if problems := lintExternalSecretReference(secrets[0]); len(problems) != 2 {
+		t.Errorf("expected a missing secret manager and an empty reference, got %v", problems)
+	}
+	if problems := lintExternalSecretReference(secrets[1]); len(problems) != 0 {
+		t.Errorf("expected no problem, got %v", problems)
+	}
+}
+
+func TestGetExternalSecretMigrations(t *testing.T) {
+	aws := "aws-id"
+	variables := []utils.EnvVarLineOutput{
+		{Id: "1", Key: "API_KEY", Scope: "CONTAINER", IsSecret: true},
+		{Id: "2", Key: "DB_PASSWORD", Scope: "CONTAINER", IsSecret: true},
+		{Id: "3", Key: "PORT", Scope: "CONTAINER"},
+		{Id: "4", Key: "TOKEN", Scope: "CONTAINER", SecretManagerAccessId: &aws},
+		{Id: "5", Key: "SHARED", Scope: "ENVIRONMENT", IsSecret: true},
+	}
+
+	tests := []struct {
+		name     string
+		keys     []string
+		mapping  map[string]string
+		expected []externalSecretMigration
+		err      bool
+	}{
+		{
+			name: "all secrets with prefix",
+			expected: []externalSecretMigration{
+				{VariableId: "1", Key: "API_KEY", Reference: "prod/API_KEY"},
+				{VariableId: "2", Key: "DB_PASSWORD", Reference: "prod/DB_PASSWORD"},
+			},
+		},
+		{
+			name:     "selected keys",
+			keys:     []string{"DB_PASSWORD"},
+			expected: []externalSecretMigration{{VariableId: "2", Key: "DB_PASSWORD", Reference: "prod/DB_PASSWORD"}},
+		},
+		{
+			name:     "mapping",
+			mapping:  map[string]string{"API_KEY": "arn:aws:secretsmanager:eu-west-3:123:secret:api"},
+			expected: []externalSecretMigration{{VariableId: "1", Key: "API_KEY", Reference: "arn:aws:secretsmanager:eu-west-3:123:secret:api"}},
+		},
+		{
+			name: "not a secret of the scope",
+			keys: []string{"PORT", "SHARED"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := getExternalSecretMigrations(variables, "service", tt.keys, tt.mapping, "prod/")
+			if tt.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(migrations, tt.expected) {
+				t.Fatalf("expected %+v, got %+v", tt.expected, migrations)
+			}
+		})
+	}
+}
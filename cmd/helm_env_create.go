@@ -50,6 +50,8 @@ var helmEnvCreateCmd = &cobra.Command{
 			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
 		}
 
+		utils.IsSecret = proposeSecret(utils.Key, utils.Value, utils.IsSecret)
+
 		err = utils.CreateServiceVariable(client, projectId, envId, helm.Id, utils.HelmScope, utils.Key, utils.Value, utils.IsSecret)
 
 		if err != nil {
@@ -24,6 +24,15 @@ var portForwardCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Capture(cmd)
 
+		if portForwardConfigFile != "" {
+			runManagedPortForward(portForwardConfigFile)
+			return
+		}
+		if portForwardDetach {
+			log.Fatal("--detach can only be used with --config")
+			return
+		}
+
 		if len(ports) == 0 {
 			log.Fatal("port flag must be specified at least once")
 			return
@@ -42,30 +51,18 @@ var portForwardCmd = &cobra.Command{
 		}
 
 		for _, port := range ports {
-			ps := strings.Split(port, ":")
-			var localPortStr, remotePortStr string
-			if len(ps) > 1 {
-				localPortStr = ps[0]
-				remotePortStr = ps[1]
-			} else {
-				localPortStr = ps[0]
-				remotePortStr = ps[0]
-			}
-
-			localPort, err := strconv.ParseUint(localPortStr, 10, 16)
+			localPort, remotePort, err := parsePortMapping(port)
 			if err != nil {
-				log.Fatal("Invalid local port {} {}", port, err)
-			}
-
-			remotePort, err := strconv.ParseUint(remotePortStr, 10, 16)
-			if err != nil {
-				log.Fatal("Invalid remote port {} {}", port, err)
+				log.Fatal(err)
 			}
 
 			req := *portForwardRequest
-			req.LocalPort = uint16(localPort)
-			req.Port = uint16(remotePort)
-			go pkg.ExecPortForward(&req)
+			req.LocalPort = localPort
+			req.Port = remotePort
+			if err := pkg.CheckPortForward(&req); err != nil {
+				log.Warnf("port %d: %v", req.Port, err)
+			}
+			go pkg.ExecPortForward(&req, nil)
 		}
 
 		done := make(chan os.Signal, 1)
@@ -74,9 +71,31 @@ var portForwardCmd = &cobra.Command{
 	},
 }
 var (
-	ports []string
+	ports                 []string
+	portForwardConfigFile string
+	portForwardDetach     bool
 )
 
+// parsePortMapping parses a "local_port:remote_port" or "port" mapping.
+func parsePortMapping(port string) (uint16, uint16, error) {
+	localPortStr, remotePortStr, found := strings.Cut(port, ":")
+	if !found {
+		remotePortStr = localPortStr
+	}
+
+	localPort, err := strconv.ParseUint(localPortStr, 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid local port %s: %w", port, err)
+	}
+
+	remotePort, err := strconv.ParseUint(remotePortStr, 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid remote port %s: %w", port, err)
+	}
+
+	return uint16(localPort), uint16(remotePort), nil
+}
+
 func portForwardRequestWithoutArg() (*pkg.PortForwardRequest, error) {
 	useContext := false
 	currentContext, err := utils.GetCurrentContext()
@@ -304,7 +323,11 @@ func init() {
 	var portForwardCmd = portForwardCmd
 	portForwardCmd.Flags().StringVarP(&podName, "pod", "", "", "pod name where to forward traffic")
 	portForwardCmd.Flags().StringSliceVarP(&ports, "port", "p", nil, "port that will be forwarded. Can be specified multiple time. Format \"local_port:remote_port\" i.e: 8080:80")
-	_ = portForwardCmd.MarkFlagRequired("port")
+	portForwardCmd.Flags().StringVar(&portForwardConfigFile, "config", "", "forward the services listed in this file, i.e: .qovery/port-forward.yaml")
+	portForwardCmd.Flags().BoolVarP(&portForwardDetach, "detach", "d", false, "run the port-forward in background, see `qovery port-forward status` and `qovery port-forward stop`")
+	portForwardCmd.Example = "qovery port-forward -p 8080:80\n" +
+		"qovery port-forward --config .qovery/port-forward.yaml\n" +
+		"qovery port-forward --config .qovery/port-forward.yaml --detach"
 
 	rootCmd.AddCommand(portForwardCmd)
 }
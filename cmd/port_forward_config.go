@@ -0,0 +1,420 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/qovery/qovery-client-go"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/pkg/usercontext"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+// portForwardDaemonEnv is set to the log file of the port-forward when it runs in background.
+const portForwardDaemonEnv = "QOVERY_PORT_FORWARD_DAEMON_LOG"
+
+// portForwardConfig is the content of a port-forward config file, i.e:
+//
+//	forwards:
+//	  - organization: my-organization # optional, defaults to the current context
+//	    project: my-project           # optional, defaults to the current context
+//	    environment: staging          # optional, defaults to the current context
+//	    service: api
+//	    pod: api-6d8f9c7b5-x2x4k      # optional
+//	    address: 127.0.0.1            # optional, defaults to localhost
+//	    ports:
+//	      - 8080:80
+type portForwardConfig struct {
+	Forwards []portForwardConfigEntry `yaml:"forwards"`
+}
+
+type portForwardConfigEntry struct {
+	Organization string   `yaml:"organization"`
+	Project      string   `yaml:"project"`
+	Environment  string   `yaml:"environment"`
+	Service      string   `yaml:"service"`
+	Pod          string   `yaml:"pod"`
+	Address      string   `yaml:"address"`
+	Ports        []string `yaml:"ports"`
+}
+
+// managedPortForward is one port forwarded by a managed port-forward.
+type managedPortForward struct {
+	Service string
+	Request pkg.PortForwardRequest
+	Metrics pkg.PortForwardMetrics
+	Error   string
+}
+
+// portForwardDaemonStatus is written by a background port-forward so that `port-forward status` can read it.
+type portForwardDaemonStatus struct {
+	Pid        int                 `json:"pid"`
+	ConfigFile string              `json:"config_file"`
+	LogFile    string              `json:"log_file"`
+	StartedAt  time.Time           `json:"started_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+	Forwards   []portForwardStatus `json:"forwards"`
+}
+
+type portForwardStatus struct {
+	Service       string               `json:"service"`
+	ListenAddress string               `json:"listen_address"`
+	LocalPort     uint16               `json:"local_port"`
+	RemotePort    uint16               `json:"remote_port"`
+	Error         string               `json:"error,omitempty"`
+	Stats         pkg.PortForwardStats `json:"stats"`
+}
+
+func loadPortForwardConfig(path string) (*portForwardConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config portForwardConfig
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("invalid port-forward config %s: %w", path, err)
+	}
+
+	if len(config.Forwards) == 0 {
+		return nil, fmt.Errorf("no forward defined in %s", path)
+	}
+	for i, entry := range config.Forwards {
+		if entry.Service == "" {
+			return nil, fmt.Errorf("forward #%d: service is required", i+1)
+		}
+		if len(entry.Ports) == 0 {
+			return nil, fmt.Errorf("forward #%d (%s): at least one port is required", i+1, entry.Service)
+		}
+		for _, port := range entry.Ports {
+			if _, _, err := parsePortMapping(port); err != nil {
+				return nil, fmt.Errorf("forward #%d (%s): %w", i+1, entry.Service, err)
+			}
+		}
+	}
+
+	return &config, nil
+}
+
+func portForwardRequestFromConfig(client *qovery.APIClient, entry portForwardConfigEntry) (*pkg.PortForwardRequest, error) {
+	organizationId, err := usercontext.GetOrganizationContextResourceId(client, entry.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	projectId, err := getProjectContextResourceId(client, entry.Project, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	environmentId, err := getEnvironmentContextResourceId(client, entry.Environment, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	environment, err := utils.GetEnvironmentById(environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := getServiceContextResourceId(client, entry.Service, environmentId)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, fmt.Errorf("service %s not found", entry.Service)
+	}
+
+	return &pkg.PortForwardRequest{
+		ServiceID:      service.ID,
+		ServiceType:    strings.ToUpper(string(service.Type)),
+		ProjectID:      utils.Id(projectId),
+		OrganizationID: utils.Id(organizationId),
+		EnvironmentID:  utils.Id(environmentId),
+		ClusterID:      environment.ClusterID,
+		PodName:        entry.Pod,
+		ListenAddress:  entry.Address,
+	}, nil
+}
+
+// runManagedPortForward forwards all the services of the config file until interrupted.
+func runManagedPortForward(configFile string) {
+	config, err := loadPortForwardConfig(configFile)
+	if err != nil {
+		utils.PrintlnError(err)
+		os.Exit(1)
+		panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+	}
+
+	if portForwardDetach {
+		if err := startPortForwardDaemon(configFile); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		return
+	}
+
+	client := utils.GetQoveryClientPanicInCaseOfError()
+
+	var forwards []*managedPortForward
+	for _, entry := range config.Forwards {
+		request, err := portForwardRequestFromConfig(client, entry)
+		if err != nil {
+			utils.PrintlnError(fmt.Errorf("%s: %w", entry.Service, err))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		for _, port := range entry.Ports {
+			// already validated when loading the config
+			localPort, remotePort, _ := parsePortMapping(port)
+			forward := &managedPortForward{Service: entry.Service, Request: *request}
+			forward.Request.LocalPort = localPort
+			forward.Request.Port = remotePort
+			forwards = append(forwards, forward)
+		}
+	}
+
+	for _, forward := range forwards {
+		if err := pkg.CheckPortForward(&forward.Request); err != nil {
+			forward.Error = err.Error()
+			log.Warnf("%s port %d: %v", forward.Service, forward.Request.Port, err)
+		}
+
+		listen, err := pkg.ListenPortForward(&forward.Request)
+		if err != nil {
+			forward.Error = err.Error()
+			log.Errorf("%s port %d: %v", forward.Service, forward.Request.Port, err)
+			continue
+		}
+		go pkg.ServePortForward(listen, &forward.Request, &forward.Metrics)
+	}
+
+	logFile, isDaemon := os.LookupEnv(portForwardDaemonEnv)
+	var status *portForwardDaemonStatus
+	var statusFile string
+	if isDaemon {
+		absoluteConfigFile, _ := filepath.Abs(configFile)
+		status = &portForwardDaemonStatus{Pid: os.Getpid(), ConfigFile: absoluteConfigFile, LogFile: logFile, StartedAt: time.Now()}
+		statusFile, err = getPortForwardStatusFile(os.Getpid())
+		if err != nil {
+			log.Error("cannot write port-forward status: ", err)
+		}
+		defer func() {
+			_ = os.Remove(statusFile)
+		}()
+	}
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if status != nil && statusFile != "" {
+			if err := writePortForwardStatus(statusFile, status, forwards); err != nil {
+				log.Error("cannot write port-forward status: ", err)
+			}
+		}
+
+		select {
+		case <-done:
+			_ = utils.PrintTable(portForwardStatusHeaders, getPortForwardStatusRows(os.Getpid(), getPortForwardStatuses(forwards)))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startPortForwardDaemon runs the port-forward of the config file in a background process.
+func startPortForwardDaemon(configFile string) error {
+	absoluteConfigFile, err := filepath.Abs(configFile)
+	if err != nil {
+		return err
+	}
+
+	directory, err := getPortForwardDirectory()
+	if err != nil {
+		return err
+	}
+
+	logPath := filepath.Join(directory, fmt.Sprintf("port-forward-%d.log", time.Now().Unix()))
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = logFile.Close()
+	}()
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	daemon := exec.Command(executable, "port-forward", "--config", absoluteConfigFile)
+	daemon.Env = append(os.Environ(), portForwardDaemonEnv+"="+logPath)
+	daemon.Stdout = logFile
+	daemon.Stderr = logFile
+	daemon.SysProcAttr = pkg.DetachedProcessAttributes()
+	if err := daemon.Start(); err != nil {
+		return err
+	}
+
+	utils.Println(fmt.Sprintf("Port-forward started in background with pid %d, logs are written to %s", daemon.Process.Pid, logPath))
+	utils.Println("Use `qovery port-forward status` to check it and `qovery port-forward stop` to stop it")
+
+	return daemon.Process.Release()
+}
+
+func getPortForwardDirectory() (string, error) {
+	qoveryDir, err := utils.QoveryDirPath()
+	if err != nil {
+		return "", err
+	}
+
+	directory := filepath.Join(qoveryDir, "port-forward")
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return "", err
+	}
+
+	return directory, nil
+}
+
+func getPortForwardStatusFile(pid int) (string, error) {
+	directory, err := getPortForwardDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(directory, strconv.Itoa(pid)+".json"), nil
+}
+
+func getPortForwardStatuses(forwards []*managedPortForward) []portForwardStatus {
+	var statuses []portForwardStatus
+	for _, forward := range forwards {
+		statuses = append(statuses, portForwardStatus{
+			Service:       forward.Service,
+			ListenAddress: forward.Request.ListenAddress,
+			LocalPort:     forward.Request.LocalPort,
+			RemotePort:    forward.Request.Port,
+			Error:         forward.Error,
+			Stats:         forward.Metrics.Stats(),
+		})
+	}
+
+	return statuses
+}
+
+func writePortForwardStatus(statusFile string, status *portForwardDaemonStatus, forwards []*managedPortForward) error {
+	status.UpdatedAt = time.Now()
+	status.Forwards = getPortForwardStatuses(forwards)
+
+	content, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	// write then rename so that readers never see a partial file
+	if err := os.WriteFile(statusFile+".tmp", content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(statusFile+".tmp", statusFile)
+}
+
+// listPortForwardDaemons returns the status of the port-forwards running in background.
+// Status files left by processes which are not running anymore are removed.
+func listPortForwardDaemons() ([]portForwardDaemonStatus, error) {
+	directory, err := getPortForwardDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(directory, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var daemons []portForwardDaemonStatus
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var status portForwardDaemonStatus
+		if err := json.Unmarshal(content, &status); err != nil {
+			return nil, fmt.Errorf("invalid port-forward status %s: %w", file, err)
+		}
+
+		if !pkg.IsProcessRunning(status.Pid) {
+			_ = os.Remove(file)
+			continue
+		}
+		daemons = append(daemons, status)
+	}
+
+	return daemons, nil
+}
+
+var portForwardStatusHeaders = []string{"Pid", "Service", "Local", "Remote", "Active Connections", "Total Connections", "Failed Connections", "Sent", "Received", "Health"}
+
+func getPortForwardStatusRows(pid int, statuses []portForwardStatus) [][]string {
+	var rows [][]string
+	for _, status := range statuses {
+		address := status.ListenAddress
+		if address == "" {
+			address = "localhost"
+		}
+
+		health := "OK"
+		if status.Error != "" {
+			health = status.Error
+		}
+
+		rows = append(rows, []string{
+			strconv.Itoa(pid),
+			status.Service,
+			fmt.Sprintf("%s:%d", address, status.LocalPort),
+			strconv.Itoa(int(status.RemotePort)),
+			strconv.FormatInt(status.Stats.ActiveConnections, 10),
+			strconv.FormatInt(status.Stats.TotalConnections, 10),
+			strconv.FormatInt(status.Stats.FailedConnections, 10),
+			formatByteCount(status.Stats.BytesSent),
+			formatByteCount(status.Stats.BytesReceived),
+			health,
+		})
+	}
+
+	return rows
+}
+
+func formatByteCount(count int64) string {
+	const unit = 1024
+	if count < unit {
+		return fmt.Sprintf("%d B", count)
+	}
+
+	div, exponent := int64(unit), 0
+	for n := count / unit; n >= unit; n /= unit {
+		div *= unit
+		exponent++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(count)/float64(div), "KMGTPE"[exponent])
+}
+
+var errNoPortForwardDaemon = errors.New("no port-forward is running in background")
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestParsePortMapping(t *testing.T) {
+	tests := []struct {
+		port       string
+		localPort  uint16
+		remotePort uint16
+		wantErr    bool
+	}{
+		{port: "8080:80", localPort: 8080, remotePort: 80},
+		{port: "5432", localPort: 5432, remotePort: 5432},
+		{port: "http:80", wantErr: true},
+		{port: "8080:70000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.port, func(t *testing.T) {
+			localPort, remotePort, err := parsePortMapping(tt.port)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if localPort != tt.localPort || remotePort != tt.remotePort {
+				t.Fatalf("expected %d:%d, got %d:%d", tt.localPort, tt.remotePort, localPort, remotePort)
+			}
+		})
+	}
+}
+
+func TestFormatByteCount(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		2048:            "2.0 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+
+	for count, expected := range tests {
+		if got := formatByteCount(count); got != expected {
+			t.Fatalf("expected %s for %d, got %s", expected, count, got)
+		}
+	}
+}
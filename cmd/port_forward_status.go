@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var portForwardStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the port-forwards running in background",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		daemons, err := listPortForwardDaemons()
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if jsonFlag {
+			if daemons == nil {
+				daemons = []portForwardDaemonStatus{}
+			}
+			j, err := json.Marshal(daemons)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			utils.Println(string(j))
+			return
+		}
+
+		if len(daemons) == 0 {
+			utils.Println(errNoPortForwardDaemon.Error())
+			return
+		}
+
+		var data [][]string
+		for _, daemon := range daemons {
+			data = append(data, getPortForwardStatusRows(daemon.Pid, daemon.Forwards)...)
+		}
+
+		if err := utils.PrintTable(portForwardStatusHeaders, data); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		for _, daemon := range daemons {
+			utils.Println(fmt.Sprintf("pid %d: started at %s from %s, logs in %s", daemon.Pid, utils.ToIso8601(&daemon.StartedAt), daemon.ConfigFile, daemon.LogFile))
+		}
+	},
+}
+
+func init() {
+	portForwardStatusCmd.Flags().BoolVarP(&jsonFlag, "json", "", false, "JSON output")
+
+	portForwardCmd.AddCommand(portForwardStatusCmd)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var portForwardStopCmd = &cobra.Command{
+	Use:   "stop [pid]",
+	Short: "Stop the port-forwards running in background",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		daemons, err := listPortForwardDaemons()
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if len(daemons) == 0 {
+			utils.PrintlnError(errNoPortForwardDaemon)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		stopped := 0
+		for _, daemon := range daemons {
+			if len(args) == 1 && args[0] != strconv.Itoa(daemon.Pid) {
+				continue
+			}
+
+			if err := stopPortForwardDaemon(daemon.Pid); err != nil {
+				utils.PrintlnError(fmt.Errorf("cannot stop port-forward %d: %w", daemon.Pid, err))
+				continue
+			}
+			stopped++
+			utils.Println(fmt.Sprintf("Port-forward %d stopped", daemon.Pid))
+		}
+
+		if stopped == 0 {
+			utils.PrintlnError(fmt.Errorf("no port-forward stopped"))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	},
+}
+
+func stopPortForwardDaemon(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+
+	// SIGTERM lets the port-forward remove its status file, it is not supported on Windows
+	if err := process.Signal(syscall.SIGTERM); err == nil {
+		return nil
+	}
+
+	if err := process.Kill(); err != nil {
+		return err
+	}
+
+	statusFile, err := getPortForwardStatusFile(pid)
+	if err != nil {
+		return err
+	}
+	return os.Remove(statusFile)
+}
+
+func init() {
+	portForwardCmd.AddCommand(portForwardStopCmd)
+}
@@ -28,6 +28,8 @@ var projectEnvCreateCmd = &cobra.Command{
 
 		project := utils.FindByProjectName(projects.GetResults(), projectName)
 
+		utils.IsSecret = proposeSecret(utils.Key, utils.Value, utils.IsSecret)
+
 		err = utils.CreateProjectVariable(client, project.Id, utils.Key, utils.Value, utils.IsSecret)
 		checkError(err)
 
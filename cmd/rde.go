@@ -18,6 +18,7 @@ import (
 const rdeBlueprintProjectIdVar = "BLUEPRINT_PROJECT_ID"
 const rdeBlueprintKeyVar = "BLUEPRINT_KEY"
 const rdeOwnerEmailVar = "RDE_OWNER_EMAIL"
+const rdeBlueprintVersionVar = "RDE_BLUEPRINT_VERSION"
 
 // RDE shared flag variables
 var rdeBlueprintProjectName string
@@ -73,6 +74,7 @@ type rdeChildInfo struct {
 	EnvName            string
 	BlueprintProjectId string
 	OwnerEmail         string
+	BlueprintVersion   string
 }
 
 // --- RDE helper functions ---
@@ -258,6 +260,11 @@ func rdeListChildren(client *qovery.APIClient, orgId string, blueprintProjectId
 				if ownerVar != nil && ownerVar.Value.IsSet() && ownerVar.Value.Get() != nil {
 					ownerEmail = *ownerVar.Value.Get()
 				}
+				blueprintVersion := ""
+				versionVar := utils.FindEnvironmentVariableByKey(rdeBlueprintVersionVar, vars)
+				if versionVar != nil && versionVar.Value.IsSet() && versionVar.Value.Get() != nil {
+					blueprintVersion = *versionVar.Value.Get()
+				}
 				children = append(children, rdeChildInfo{
 					ProjectId:          project.Id,
 					ProjectName:        project.Name,
@@ -265,6 +272,7 @@ func rdeListChildren(client *qovery.APIClient, orgId string, blueprintProjectId
 					EnvName:            env.Name,
 					BlueprintProjectId: blueprintProjectId,
 					OwnerEmail:         ownerEmail,
+					BlueprintVersion:   blueprintVersion,
 				})
 			}
 		}
@@ -332,6 +340,11 @@ func rdeFindChildByName(client *qovery.APIClient, orgId string, name string) (*r
 				if ownerVar != nil && ownerVar.Value.IsSet() && ownerVar.Value.Get() != nil {
 					ownerEmail = *ownerVar.Value.Get()
 				}
+				blueprintVersion := ""
+				versionVar := utils.FindEnvironmentVariableByKey(rdeBlueprintVersionVar, vars)
+				if versionVar != nil && versionVar.Value.IsSet() && versionVar.Value.Get() != nil {
+					blueprintVersion = *versionVar.Value.Get()
+				}
 				return &rdeChildInfo{
 					ProjectId:          project.Id,
 					ProjectName:        project.Name,
@@ -339,6 +352,7 @@ func rdeFindChildByName(client *qovery.APIClient, orgId string, name string) (*r
 					EnvName:            env.Name,
 					BlueprintProjectId: val,
 					OwnerEmail:         ownerEmail,
+					BlueprintVersion:   blueprintVersion,
 				}, nil
 			}
 		}
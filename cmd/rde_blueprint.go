@@ -13,7 +13,10 @@ var rdeBlueprintCmd = &cobra.Command{
 
 A blueprint is a project with a template environment that serves as the source
 for cloning new Remote Development Environments. Blueprints are identified by
-a project-level environment variable BLUEPRINT_PROJECT_ID.`,
+a project-level environment variable BLUEPRINT_PROJECT_ID.
+
+The service versions of a blueprint can be recorded as releases, which RDEs are
+pinned to with 'qovery rde create --blueprint-version'.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Capture(cmd)
 
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+)
+
+// rdeBlueprintReleaseVarPrefix prefixes the project variables holding the releases of a blueprint
+const rdeBlueprintReleaseVarPrefix = "RDE_BLUEPRINT_RELEASE_"
+
+// rdeBlueprintHead is the blueprint version of the RDEs tracking the current state of their blueprint
+const rdeBlueprintHead = "head"
+
+// RDE blueprint release flag variables
+var rdeBlueprintVersion string
+
+var rdeBlueprintReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Record the current service versions of a blueprint as a release",
+	Long: `Record the current service versions of a blueprint as an immutable release.
+
+A release captures the version (container tags, git branches and their deployed commits, chart versions)
+and the resources (CPU, memory, instances) of each service of the blueprint environment. It is stored as
+a JSON project variable of the blueprint (RDE_BLUEPRINT_RELEASE_<VERSION>) and cannot be replaced.
+
+The applications, jobs and helms built from git are deployed at the recorded commit when the release
+is applied by 'qovery rde create' or 'qovery rde upgrade'. Their services still track the branch:
+later deployments, from the console or 'qovery rde start', deploy the latest commit of the branch.
+
+RDEs are pinned to a release with 'qovery rde create --blueprint-version', and rolled forward
+with 'qovery rde upgrade --blueprint-version'. RDEs created without a version track the blueprint head.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if err := rdeValidateBlueprintVersion(rdeBlueprintVersion); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		bp, err := rdeFindBlueprintByProjectName(client, orgId, rdeBlueprintProjectName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+		if bp.EnvId == "" {
+			utils.PrintlnError(fmt.Errorf("blueprint %s has no environment with %s set", bp.ProjectName, rdeBlueprintKeyVar))
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		release, err := rdeCreateBlueprintRelease(client, bp, rdeBlueprintVersion)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		utils.Println(fmt.Sprintf("Release %s of blueprint %s has been recorded (%d service(s))",
+			pterm.FgBlue.Sprintf("%s", release.Version), pterm.FgBlue.Sprintf("%s", bp.ProjectName), len(release.Services)))
+	},
+}
+
+func init() {
+	rdeBlueprintCmd.AddCommand(rdeBlueprintReleaseCmd)
+	rdeBlueprintReleaseCmd.Flags().StringVarP(&rdeBlueprintProjectName, "project", "p", "", "Blueprint Project Name")
+	rdeBlueprintReleaseCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeBlueprintReleaseCmd.Flags().StringVarP(&rdeBlueprintVersion, "version", "", "", "Version of the release (e.g. 1.4)")
+	rdeBlueprintReleaseCmd.Example = "qovery rde blueprint release --project blueprint-backend --version 1.4"
+
+	_ = rdeBlueprintReleaseCmd.MarkFlagRequired("project")
+	_ = rdeBlueprintReleaseCmd.MarkFlagRequired("version")
+}
+
+// rdeBlueprintRelease is the service versions of a blueprint at a release, as stored in its project variables.
+type rdeBlueprintRelease struct {
+	Version   string               `json:"version"`
+	CreatedAt time.Time            `json:"created_at"`
+	Services  []rdeServiceSnapshot `json:"services"`
+}
+
+// rdeValidateBlueprintVersion returns an error when version cannot name a release.
+func rdeValidateBlueprintVersion(version string) error {
+	if version == "" {
+		return fmt.Errorf("--version is required")
+	}
+	if strings.EqualFold(version, rdeBlueprintHead) {
+		return fmt.Errorf("%s is reserved for the RDEs tracking the blueprint head", rdeBlueprintHead)
+	}
+	for _, r := range version {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '.' && r != '-' && r != '_' {
+			return fmt.Errorf("invalid version %s: only letters, digits, '.', '-' and '_' are allowed", version)
+		}
+	}
+	return nil
+}
+
+// rdeBlueprintReleaseVariableKey returns the project variable holding the release version.
+func rdeBlueprintReleaseVariableKey(version string) string {
+	return rdeVariableKey(rdeBlueprintReleaseVarPrefix, version)
+}
+
+// rdeListBlueprintReleases returns the releases stored in the project variables of the blueprint, oldest first.
+func rdeListBlueprintReleases(client *qovery.APIClient, blueprintProjectId string) ([]rdeBlueprintRelease, error) {
+	vars, err := utils.ListProjectVariables(client, blueprintProjectId)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []rdeBlueprintRelease
+	for _, v := range vars {
+		if !strings.HasPrefix(v.Key, rdeBlueprintReleaseVarPrefix) || !v.Value.IsSet() || v.Value.Get() == nil {
+			continue
+		}
+		var release rdeBlueprintRelease
+		if err := json.Unmarshal([]byte(*v.Value.Get()), &release); err != nil {
+			return nil, fmt.Errorf("invalid release %s: %w", v.Key, err)
+		}
+		releases = append(releases, release)
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].CreatedAt.Before(releases[j].CreatedAt)
+	})
+	return releases, nil
+}
+
+// rdeGetBlueprintRelease returns the release version of the blueprint.
+func rdeGetBlueprintRelease(client *qovery.APIClient, blueprintProjectId string, version string) (*rdeBlueprintRelease, error) {
+	releases, err := rdeListBlueprintReleases(client, blueprintProjectId)
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		if release.Version == version {
+			return &release, nil
+		}
+	}
+	return nil, fmt.Errorf("release %s of blueprint %s not found", version, rdeBlueprintNameForProjectId(client, blueprintProjectId))
+}
+
+// rdeCreateBlueprintRelease records the current service versions of the blueprint as the release version.
+// Releases are immutable: recording an existing version fails.
+func rdeCreateBlueprintRelease(client *qovery.APIClient, bp *rdeBlueprintInfo, version string) (*rdeBlueprintRelease, error) {
+	key := rdeBlueprintReleaseVariableKey(version)
+
+	vars, err := utils.ListProjectVariables(client, bp.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if utils.FindEnvironmentVariableByKey(key, vars) != nil {
+		return nil, fmt.Errorf("release %s of blueprint %s already exists (%s), releases cannot be replaced", version, bp.ProjectName, key)
+	}
+
+	_, services, err := rdeListServices(client, bp.EnvId)
+	if err != nil {
+		return nil, err
+	}
+
+	release := &rdeBlueprintRelease{Version: version, CreatedAt: time.Now().UTC(), Services: services}
+	content, err := json.Marshal(release)
+	if err != nil {
+		return nil, err
+	}
+	return release, utils.CreateProjectVariable(client, bp.ProjectId, key, string(content), false)
+}
+
+// rdeApplyBlueprintRelease sets the versions and resources of the release on the services of the environment of an RDE,
+// and pins the RDE to the release. Each service is reported to log, and the number of services updated is returned.
+func rdeApplyBlueprintRelease(client *qovery.APIClient, projectId string, envId string, release *rdeBlueprintRelease, log func(string)) int {
+	applied := rdeRestoreServices(client, envId, release.Services, log)
+	if err := rdePinBlueprintVersion(client, projectId, envId, release.Version); err != nil {
+		log(fmt.Sprintf("    WARNING: Failed to record blueprint version %s: %v", release.Version, err))
+	}
+	return applied
+}
+
+// rdePinBlueprintVersion records the blueprint version of the environment of an RDE. An empty version unpins the RDE,
+// which then tracks the blueprint head.
+func rdePinBlueprintVersion(client *qovery.APIClient, projectId string, envId string, version string) error {
+	vars, err := utils.ListEnvironmentVariables(client, envId)
+	if err != nil {
+		return err
+	}
+	existing := utils.FindEnvironmentVariableByKey(rdeBlueprintVersionVar, vars)
+
+	if version == "" {
+		if existing == nil {
+			return nil
+		}
+		return utils.DeleteEnvironmentVar(client, envId, rdeBlueprintVersionVar)
+	}
+	if existing != nil {
+		return utils.UpdateEnvironmentVariable(client, envId, rdeBlueprintVersionVar, version)
+	}
+	return utils.CreateEnvironmentVariable(client, projectId, envId, rdeBlueprintVersionVar, version, false)
+}
+
+// rdeSplitPinnedChildren returns the RDEs tracking the blueprint head, and those pinned to a blueprint release.
+func rdeSplitPinnedChildren(children []rdeChildInfo) ([]rdeChildInfo, []rdeChildInfo) {
+	var tracking, pinned []rdeChildInfo
+	for _, child := range children {
+		if child.BlueprintVersion == "" {
+			tracking = append(tracking, child)
+		} else {
+			pinned = append(pinned, child)
+		}
+	}
+	return tracking, pinned
+}
+
+// rdeFormatBlueprintVersion returns the blueprint version of an RDE for display.
+func rdeFormatBlueprintVersion(version string) string {
+	if version == "" {
+		return rdeBlueprintHead
+	}
+	return version
+}
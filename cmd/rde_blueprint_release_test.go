@@ -0,0 +1,47 @@
+package cmd
+
+import "testing"
+
+func TestRdeValidateBlueprintVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		valid   bool
+	}{
+		{version: "1.4", valid: true},
+		{version: "2024-06_rc1", valid: true},
+		{version: "", valid: false},
+		{version: "HEAD", valid: false},
+		{version: "1.4 beta", valid: false},
+		{version: "v1/2", valid: false},
+	}
+
+	for _, tt := range tests {
+		if err := rdeValidateBlueprintVersion(tt.version); (err == nil) != tt.valid {
+			t.Errorf("version %q: unexpected result %v", tt.version, err)
+		}
+	}
+}
+
+func TestRdeBlueprintReleaseVariableKey(t *testing.T) {
+	if key := rdeBlueprintReleaseVariableKey("1.4-rc1"); key != "RDE_BLUEPRINT_RELEASE_1_4_RC1" {
+		t.Errorf("unexpected key %s", key)
+	}
+}
+
+func TestRdeSplitPinnedChildren(t *testing.T) {
+	tracking, pinned := rdeSplitPinnedChildren([]rdeChildInfo{
+		{ProjectName: "rde-alice"},
+		{ProjectName: "rde-bob", BlueprintVersion: "1.4"},
+		{ProjectName: "rde-carol"},
+	})
+
+	if len(tracking) != 2 || tracking[0].ProjectName != "rde-alice" || tracking[1].ProjectName != "rde-carol" {
+		t.Errorf("unexpected tracking RDEs %+v", tracking)
+	}
+	if len(pinned) != 1 || pinned[0].ProjectName != "rde-bob" {
+		t.Errorf("unexpected pinned RDEs %+v", pinned)
+	}
+	if rdeFormatBlueprintVersion(tracking[0].BlueprintVersion) != rdeBlueprintHead || rdeFormatBlueprintVersion(pinned[0].BlueprintVersion) != "1.4" {
+		t.Error("unexpected formatted versions")
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var rdeBlueprintReleasesCmd = &cobra.Command{
+	Use:   "releases",
+	Short: "List the releases of a blueprint",
+	Long:  `List the releases of a blueprint, oldest first, with the number of RDEs pinned to each of them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		bp, err := rdeFindBlueprintByProjectName(client, orgId, rdeBlueprintProjectName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		releases, err := rdeListBlueprintReleases(client, bp.ProjectId)
+		checkError(err)
+
+		children, err := rdeListChildren(client, orgId, bp.ProjectId)
+		checkError(err)
+		pinned := make(map[string]int)
+		for _, child := range children {
+			pinned[rdeFormatBlueprintVersion(child.BlueprintVersion)]++
+		}
+
+		if jsonFlag {
+			var results []interface{}
+			for _, release := range releases {
+				results = append(results, map[string]interface{}{
+					"version":    release.Version,
+					"created_at": utils.ToIso8601(&release.CreatedAt),
+					"services":   release.Services,
+					"rdes":       pinned[release.Version],
+				})
+			}
+			j, err := json.Marshal(results)
+			checkError(err)
+			utils.Println(string(j))
+			return
+		}
+
+		if len(releases) == 0 {
+			utils.Println(fmt.Sprintf("No releases found for blueprint %s.", bp.ProjectName))
+			return
+		}
+
+		var data [][]string
+		for _, release := range releases {
+			data = append(data, []string{
+				release.Version,
+				release.CreatedAt.Local().Format(time.RFC1123),
+				strconv.Itoa(len(release.Services)),
+				strconv.Itoa(pinned[release.Version]),
+			})
+		}
+
+		err = utils.PrintTable([]string{"Version", "Created At", "Services", "RDEs"}, data)
+		checkError(err)
+
+		utils.Println(fmt.Sprintf("\n%d RDE(s) tracking the blueprint head", pinned[rdeBlueprintHead]))
+	},
+}
+
+func init() {
+	rdeBlueprintCmd.AddCommand(rdeBlueprintReleasesCmd)
+	rdeBlueprintReleasesCmd.Flags().StringVarP(&rdeBlueprintProjectName, "project", "p", "", "Blueprint Project Name")
+	rdeBlueprintReleasesCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeBlueprintReleasesCmd.Flags().BoolVarP(&jsonFlag, "json", "", false, "JSON output")
+
+	_ = rdeBlueprintReleasesCmd.MarkFlagRequired("project")
+}
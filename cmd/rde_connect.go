@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+)
+
+// RDE connect flag variables
+var rdeConnectSSH bool
+var rdeConnectStdio bool
+var rdeConnectUser string
+var rdeConnectPort int
+var rdeConnectService string
+var rdeConnectSSHConfig string
+
+var rdeConnectCmd = &cobra.Command{
+	Use:   "connect",
+	Short: "Connect a local IDE or terminal to an RDE over SSH",
+	Long: `Connect a local IDE or terminal to an RDE over SSH.
+
+With --ssh, an entry 'qovery-rde-<name>' is written to ~/.ssh/config. Its ProxyCommand runs
+'qovery rde connect --stdio', which reaches the sshd of the workspace through the Qovery
+port-forward: the workspace does not need to be exposed publicly, but it must run sshd on --port.
+The entry is replaced when the command is run again.
+
+Then connect with 'ssh qovery-rde-<name>', or open VS Code with 'qovery rde open --vscode'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if !rdeConnectSSH && !rdeConnectStdio {
+			_ = cmd.Help()
+			os.Exit(0)
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		req, err := rdePortForwardRequest(client, orgId, rdeName, rdeConnectService, rdeConnectPort)
+
+		if rdeConnectStdio {
+			// stdout is the ssh connection, errors go to stderr
+			if err == nil {
+				err = pkg.ForwardStdio(req, os.Stdin, os.Stdout)
+			}
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		path, err := rdeSSHConfigPath(rdeConnectSSHConfig)
+		checkError(err)
+
+		host, err := rdeWriteSSHConfig(rdeName, rdeConnectUser, rdeConnectPort, rdeConnectService, path)
+		checkError(err)
+
+		utils.Println(fmt.Sprintf("SSH entry %s written to %s.", host, path))
+		utils.Println(fmt.Sprintf("Connect with: ssh %s", host))
+	},
+}
+
+func init() {
+	rdeCmd.AddCommand(rdeConnectCmd)
+	rdeConnectCmd.Flags().StringVarP(&rdeName, "name", "n", "", "RDE Name")
+	rdeConnectCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeConnectCmd.Flags().BoolVarP(&rdeConnectSSH, "ssh", "", false, "Write an SSH config entry for the RDE")
+	rdeConnectCmd.Flags().BoolVarP(&rdeConnectStdio, "stdio", "", false, "Forward stdin and stdout to the sshd of the RDE (used as SSH ProxyCommand)")
+	rdeConnectCmd.Flags().StringVarP(&rdeConnectUser, "user", "u", "root", "SSH user of the workspace")
+	rdeConnectCmd.Flags().IntVarP(&rdeConnectPort, "port", "p", 22, "Port of the sshd of the workspace")
+	rdeConnectCmd.Flags().StringVarP(&rdeConnectService, "service", "", "", "Service running sshd (default: the workspace application)")
+	rdeConnectCmd.Flags().StringVarP(&rdeConnectSSHConfig, "ssh-config", "", "", "SSH config file to write (default ~/.ssh/config)")
+	_ = rdeConnectCmd.Flags().MarkHidden("stdio")
+
+	_ = rdeConnectCmd.MarkFlagRequired("name")
+}
+
+// rdeSSHHost returns the SSH host of the RDE name.
+func rdeSSHHost(name string) string {
+	return "qovery-rde-" + name
+}
+
+// rdeSSHConfigPath returns path, or the SSH config file of the user when path is empty.
+func rdeSSHConfigPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ssh", "config"), nil
+}
+
+// rdePortForwardRequest returns the port-forward to the port of the service of the RDE name,
+// or of its workspace application when service is empty.
+func rdePortForwardRequest(client *qovery.APIClient, orgId string, name string, service string, port int) (*pkg.PortForwardRequest, error) {
+	if port <= 0 || port > 65535 {
+		return nil, fmt.Errorf("invalid port %d", port)
+	}
+
+	child, err := rdeFindChildByName(client, orgId, fmt.Sprintf("rde-%s", name))
+	if err != nil {
+		return nil, err
+	}
+	if child.EnvId == "" {
+		return nil, fmt.Errorf("RDE %s has no environment", name)
+	}
+
+	services, _, err := rdeListServices(client, child.EnvId)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *utils.Service
+	for i := range services {
+		if (service == "" && services[i].Type == utils.ApplicationType) || (service != "" && string(services[i].Name) == service) {
+			target = &services[i]
+			break
+		}
+	}
+	if target == nil {
+		if service == "" {
+			return nil, fmt.Errorf("no workspace application found in RDE %s, use --service", name)
+		}
+		return nil, fmt.Errorf("service %s not found in RDE %s", service, name)
+	}
+
+	env, _, err := client.EnvironmentMainCallsAPI.GetEnvironment(ctx(), child.EnvId).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pkg.PortForwardRequest{
+		ServiceID:      target.ID,
+		ServiceType:    strings.ToUpper(string(target.Type)),
+		ProjectID:      utils.Id(child.ProjectId),
+		OrganizationID: utils.Id(orgId),
+		EnvironmentID:  utils.Id(child.EnvId),
+		ClusterID:      utils.Id(env.ClusterId),
+		Port:           uint16(port),
+	}, nil
+}
+
+// rdeSSHConfigEntry returns the SSH config entry of host.
+// The host key of the workspace changes on each deployment and the connection goes through the authenticated
+// Qovery port-forward, so host keys are not checked.
+func rdeSSHConfigEntry(host string, user string, proxyCommand string) string {
+	return fmt.Sprintf(`# BEGIN qovery %[1]s
+Host %[1]s
+  User %[2]s
+  ProxyCommand %[3]s
+  StrictHostKeyChecking no
+  UserKnownHostsFile /dev/null
+  ServerAliveInterval 30
+# END qovery %[1]s
+`, host, user, proxyCommand)
+}
+
+// rdeUpsertSSHConfig returns the SSH config with the entry of host replaced by entry, or appended when missing.
+func rdeUpsertSSHConfig(config string, host string, entry string) string {
+	begin := "# BEGIN qovery " + host + "\n"
+	end := "# END qovery " + host + "\n"
+
+	if start := strings.Index(config, begin); start >= 0 {
+		if stop := strings.Index(config[start:], end); stop >= 0 {
+			return config[:start] + entry + config[start+stop+len(end):]
+		}
+	}
+
+	if config != "" && !strings.HasSuffix(config, "\n") {
+		config += "\n"
+	}
+	if config != "" {
+		config += "\n"
+	}
+	return config + entry
+}
+
+// rdeQuoteProxyArg quotes arg for the ProxyCommand of an SSH config on goos.
+// OpenSSH runs the ProxyCommand with sh on POSIX systems, and splits it with the rules of the Windows command line otherwise.
+// '%' is escaped as OpenSSH expands the tokens of the ProxyCommand.
+func rdeQuoteProxyArg(arg string, goos string) string {
+	arg = strings.ReplaceAll(arg, "%", "%%")
+	if goos == "windows" {
+		return `"` + arg + `"`
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// rdeWriteSSHConfig writes the SSH config entry of the RDE name to the file path and returns its host.
+func rdeWriteSSHConfig(name string, user string, port int, service string, path string) (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	quote := func(arg string) string {
+		return rdeQuoteProxyArg(arg, runtime.GOOS)
+	}
+	proxyCommand := fmt.Sprintf("%s rde connect --stdio --name %s --port %d", quote(executable), quote(name), port)
+	if service != "" {
+		proxyCommand += fmt.Sprintf(" --service %s", quote(service))
+	}
+	if organizationName != "" {
+		proxyCommand += fmt.Sprintf(" --organization %s", quote(organizationName))
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	host := rdeSSHHost(name)
+	config := rdeUpsertSSHConfig(string(content), host, rdeSSHConfigEntry(host, user, proxyCommand))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	return host, os.WriteFile(path, []byte(config), 0600)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestRdeUpsertSSHConfig(t *testing.T) {
+	entry := rdeSSHConfigEntry("qovery-rde-alice", "root", "qovery rde connect --stdio --name alice --port 22")
+	updated := rdeSSHConfigEntry("qovery-rde-alice", "dev", "qovery rde connect --stdio --name alice --port 2222")
+	other := rdeSSHConfigEntry("qovery-rde-bob", "root", "qovery rde connect --stdio --name bob --port 22")
+	existing := "Host github.com\n  User git"
+
+	tests := []struct {
+		config   string
+		entry    string
+		expected string
+	}{
+		{"", entry, entry},
+		{existing, entry, existing + "\n\n" + entry},
+		{existing + "\n\n" + entry, updated, existing + "\n\n" + updated},
+		{entry + "\n" + other, updated, updated + "\n" + other},
+	}
+
+	for _, tt := range tests {
+		if config := rdeUpsertSSHConfig(tt.config, "qovery-rde-alice", tt.entry); config != tt.expected {
+			t.Errorf("rdeUpsertSSHConfig(%q) = %q, expected %q", tt.config, config, tt.expected)
+		}
+	}
+}
+
+func TestRdeQuoteProxyArg(t *testing.T) {
+	tests := []struct {
+		arg      string
+		goos     string
+		expected string
+	}{
+		{"/usr/local/bin/qovery", "linux", "'/usr/local/bin/qovery'"},
+		{"/Users/alice/My Tools/qovery", "darwin", "'/Users/alice/My Tools/qovery'"},
+		{"Bob's Org", "linux", `'Bob'\''s Org'`},
+		{"100%", "linux", "'100%%'"},
+		{`C:\Program Files\Qovery\qovery.exe`, "windows", `"C:\Program Files\Qovery\qovery.exe"`},
+		{"Bob's Org", "windows", `"Bob's Org"`},
+	}
+
+	for _, tt := range tests {
+		if quoted := rdeQuoteProxyArg(tt.arg, tt.goos); quoted != tt.expected {
+			t.Errorf("rdeQuoteProxyArg(%q, %q) = %s, expected %s", tt.arg, tt.goos, quoted, tt.expected)
+		}
+	}
+}
+
+func TestRdeVSCodeURI(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/", "vscode://vscode-remote/ssh-remote+qovery-rde-alice/"},
+		{"/home/dev/project", "vscode://vscode-remote/ssh-remote+qovery-rde-alice/home/dev/project"},
+		{"workspace", "vscode://vscode-remote/ssh-remote+qovery-rde-alice/workspace"},
+	}
+
+	for _, tt := range tests {
+		if uri := rdeVSCodeURI("qovery-rde-alice", tt.path); uri != tt.expected {
+			t.Errorf("rdeVSCodeURI(%q) = %q, expected %q", tt.path, uri, tt.expected)
+		}
+	}
+}
@@ -19,10 +19,13 @@ var rdeCreateCmd = &cobra.Command{
 This command:
   1. Creates a new project for the RDE
   2. Creates an RBAC role with scoped permissions (unless --skip-rbac)
-  3. Clones the blueprint environment into the new project
+  3. Clones the blueprint environment into the new project, and applies the
+     service versions of the --blueprint-version release (if provided)
   4. Updates the TTL job to target the new environment (if present)
   5. Invites the developer via email (unless --skip-invite)
-  6. Triggers deployment (unless --skip-deploy)`,
+  6. Triggers deployment (unless --skip-deploy)
+
+Without --blueprint-version, the RDE tracks the blueprint head.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Capture(cmd)
 
@@ -61,6 +64,20 @@ This command:
 		}
 		utils.Println(fmt.Sprintf("  Blueprint: %s (env: %s)", bp.ProjectName, bp.EnvId))
 
+		var release *rdeBlueprintRelease
+		if rdeBlueprintVersion != "" && rdeBlueprintVersion != rdeBlueprintHead {
+			release, err = rdeGetBlueprintRelease(client, bp.ProjectId, rdeBlueprintVersion)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable")
+			}
+			utils.Println(fmt.Sprintf("  Blueprint version: %s", release.Version))
+		}
+
+		// The RDE counts against the quotas of its owner
+		rdeEnforceQuota(client, orgId, rdeEmail, true, !rdeSkipDeploy, "")
+
 		// Step 2: Create project
 		projectName := fmt.Sprintf("rde-%s", rdeName)
 		utils.Println(fmt.Sprintf("\nStep 1/6: Creating project %s...", pterm.FgBlue.Sprintf("%s", projectName)))
@@ -82,6 +99,13 @@ This command:
 		}
 		utils.Println(fmt.Sprintf("  Project: %s", project.Id))
 
+		if rdeTTLHours > 0 || rdeStopAfterDeploy != "" {
+			err = rdeSetTTLPolicy(client, project.Id, rdeTTLHours, rdeStopAfterDeploy)
+			if err != nil {
+				utils.PrintlnInfo(fmt.Sprintf("Failed to set the TTL policy: %v (set it with 'qovery rde ttl set')", err))
+			}
+		}
+
 		// Step 3: Create RBAC role
 		var roleId string
 		if !rdeSkipRbac {
@@ -186,9 +210,15 @@ This command:
 			_ = utils.CreateEnvironmentVariable(client, project.Id, clonedEnv.Id, rdeOwnerEmailVar, rdeEmail, false)
 		}
 
+		// Pin the services to the blueprint release if requested
+		if release != nil {
+			utils.Println(fmt.Sprintf("  Applying blueprint version %s...", pterm.FgBlue.Sprintf("%s", release.Version)))
+			rdeApplyBlueprintRelease(client, project.Id, clonedEnv.Id, release, utils.Println)
+		}
+
 		// Step 5: Update TTL job (if present)
 		utils.Println("\nStep 4/6: Checking for TTL job...")
-		rdeUpdateTTLJob(client, clonedEnv.Id)
+		rdeUpdateTTLJob(client, clonedEnv.Id, utils.Println)
 
 		// Step 6: Invite member
 		if !rdeSkipInvite && rdeEmail != "" {
@@ -211,7 +241,11 @@ This command:
 		// Step 7: Deploy
 		if !rdeSkipDeploy {
 			utils.Println("\nStep 6/6: Deploying...")
-			_, _, err = client.EnvironmentActionsAPI.DeployEnvironment(ctx(), clonedEnv.Id).Execute()
+			var pinned []rdeServiceSnapshot
+			if release != nil {
+				pinned = release.Services
+			}
+			err = rdeDeployServices(client, clonedEnv.Id, pinned)
 			if err != nil {
 				utils.PrintlnInfo(fmt.Sprintf("Deploy failed: %v (deploy from Console)", err))
 			} else {
@@ -316,11 +350,11 @@ func rdeSetRolePermissions(client *qovery.APIClient, orgId string, roleId string
 	return err
 }
 
-// rdeUpdateTTLJob finds and updates the ttl-auto-shutdown job in the environment.
-func rdeUpdateTTLJob(client *qovery.APIClient, envId string) {
+// rdeUpdateTTLJob finds and updates the ttl-auto-shutdown job in the environment, reporting to log.
+func rdeUpdateTTLJob(client *qovery.APIClient, envId string, log func(string)) {
 	jobs, _, err := client.JobsAPI.ListJobs(ctx(), envId).Execute()
 	if err != nil {
-		utils.Println("  No jobs found (non-critical)")
+		log("  No jobs found (non-critical)")
 		return
 	}
 
@@ -328,29 +362,32 @@ func rdeUpdateTTLJob(client *qovery.APIClient, envId string) {
 		jobName := utils.GetJobName(&job)
 		if jobName == "ttl-auto-shutdown" {
 			jobId := utils.GetJobId(&job)
-			utils.Println(fmt.Sprintf("  Found TTL job: %s", jobId))
+			log(fmt.Sprintf("  Found TTL job: %s", jobId))
 			// The TTL job is cloned from the blueprint and may reference the blueprint env ID
 			// in its arguments. We don't modify the curl command here since the token would
 			// also need updating. The TTL job will work as-is if the SHUTDOWN_TOKEN env var
 			// is properly set on the job.
-			utils.Println("  TTL job preserved from blueprint clone")
+			log("  TTL job preserved from blueprint clone")
 			return
 		}
 	}
 
-	utils.Println("  No TTL job found (non-critical)")
+	log("  No TTL job found (non-critical)")
 }
 
 func init() {
 	rdeCmd.AddCommand(rdeCreateCmd)
 	rdeCreateCmd.Flags().StringVarP(&rdeBlueprintProjectName, "blueprint", "b", "", "Blueprint Project Name to clone from")
 	rdeCreateCmd.Flags().StringVarP(&rdeName, "name", "n", "", "Name for the new RDE (will create project rde-<name>)")
-	rdeCreateCmd.Flags().StringVarP(&rdeEmail, "email", "e", "", "Email address to invite the developer")
+	rdeCreateCmd.Flags().StringVarP(&rdeEmail, "email", "e", "", "Email address of the developer owning the RDE, invited to the organization (required when RDE quotas are configured)")
 	rdeCreateCmd.Flags().StringVarP(&clusterName, "cluster", "c", "", "Cluster Name where to create the RDE")
 	rdeCreateCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
 	rdeCreateCmd.Flags().BoolVarP(&rdeSkipRbac, "skip-rbac", "", false, "Skip RBAC role creation")
 	rdeCreateCmd.Flags().BoolVarP(&rdeSkipInvite, "skip-invite", "", false, "Skip member invitation")
 	rdeCreateCmd.Flags().BoolVarP(&rdeSkipDeploy, "skip-deploy", "", false, "Skip deployment after cloning")
+	rdeCreateCmd.Flags().IntVarP(&rdeTTLHours, "ttl-hours", "", 0, "Hours until the RDE expires and is reaped by 'qovery rde reap'")
+	rdeCreateCmd.Flags().StringVarP(&rdeBlueprintVersion, "blueprint-version", "", "", "Blueprint release to pin the RDE to (default: track the blueprint head)")
+	rdeCreateCmd.Flags().StringVarP(&rdeStopAfterDeploy, "stop-after-deploy", "", "", "Stop the RDE when still running this long after its last deployment (e.g. 8h, 30m)")
 
 	_ = rdeCreateCmd.MarkFlagRequired("blueprint")
 	_ = rdeCreateCmd.MarkFlagRequired("name")
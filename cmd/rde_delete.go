@@ -27,52 +27,63 @@ var rdeDeleteCmd = &cobra.Command{
 		orgId, err := rdeGetOrgId(client)
 		checkError(err)
 
-		projectName := fmt.Sprintf("rde-%s", rdeName)
-		utils.Println(fmt.Sprintf("Deleting RDE %s...", pterm.FgBlue.Sprintf("%s", rdeName)))
-
-		// Find the project
-		project, err := rdeFindProjectByName(client, orgId, projectName)
+		err = rdeDeleteByName(client, orgId, rdeName)
 		if err != nil {
-			utils.PrintlnError(fmt.Errorf("RDE %s not found (no project %s)", rdeName, projectName))
-			// Still try to clean up role and token
-			rdeCleanupRoleAndToken(client, orgId, rdeName)
+			utils.PrintlnError(err)
 			os.Exit(1)
 			panic("unreachable")
 		}
 
-		// Find environment
-		environments, _, err := client.EnvironmentsAPI.ListEnvironment(ctx(), project.Id).Execute()
-		if err == nil {
-			for _, env := range environments.GetResults() {
-				// Stop environment
-				status, _ := rdeGetEnvStatus(client, env.Id)
-				if status != qovery.STATEENUM_STOPPED && status != "" {
-					utils.Println(fmt.Sprintf("  Stopping environment %s...", pterm.FgBlue.Sprintf("%s", env.Name)))
-					_, _, _ = client.EnvironmentActionsAPI.StopEnvironment(ctx(), env.Id).Execute()
-					time.Sleep(2 * time.Second)
-				}
+		utils.Println(fmt.Sprintf("\nRDE %s fully removed.", pterm.FgBlue.Sprintf("%s", rdeName)))
+	},
+}
 
-				// Delete environment
-				utils.Println(fmt.Sprintf("  Deleting environment %s...", pterm.FgBlue.Sprintf("%s", env.Name)))
-				_, err = client.EnvironmentMainCallsAPI.DeleteEnvironment(ctx(), env.Id).Execute()
-				if err != nil {
-					utils.PrintlnInfo(fmt.Sprintf("Failed to delete environment: %v", err))
-				}
+// rdeDeleteByName stops and deletes the environments and the project of an RDE, then its RBAC role and API token.
+func rdeDeleteByName(client *qovery.APIClient, orgId string, name string) error {
+	projectName := fmt.Sprintf("rde-%s", name)
+	utils.Println(fmt.Sprintf("Deleting RDE %s...", pterm.FgBlue.Sprintf("%s", name)))
+
+	// Find the project
+	project, err := rdeFindProjectByName(client, orgId, projectName)
+	if err != nil {
+		// Still try to clean up role and token
+		rdeCleanupRoleAndToken(client, orgId, name)
+		return fmt.Errorf("RDE %s not found (no project %s)", name, projectName)
+	}
+
+	// Find environment
+	environments, _, err := client.EnvironmentsAPI.ListEnvironment(ctx(), project.Id).Execute()
+	if err == nil {
+		for _, env := range environments.GetResults() {
+			// Stop environment
+			status, _ := rdeGetEnvStatus(client, env.Id)
+			if status != qovery.STATEENUM_STOPPED && status != "" {
+				utils.Println(fmt.Sprintf("  Stopping environment %s...", pterm.FgBlue.Sprintf("%s", env.Name)))
+				_, _, _ = client.EnvironmentActionsAPI.StopEnvironment(ctx(), env.Id).Execute()
+				time.Sleep(2 * time.Second)
 			}
-		}
 
-		// Delete project
-		utils.Println(fmt.Sprintf("  Deleting project %s...", pterm.FgBlue.Sprintf("%s", projectName)))
-		_, err = client.ProjectMainCallsAPI.DeleteProject(ctx(), project.Id).Execute()
-		if err != nil {
-			utils.PrintlnInfo(fmt.Sprintf("Failed to delete project: %v", err))
+			// Delete environment
+			utils.Println(fmt.Sprintf("  Deleting environment %s...", pterm.FgBlue.Sprintf("%s", env.Name)))
+			_, err = client.EnvironmentMainCallsAPI.DeleteEnvironment(ctx(), env.Id).Execute()
+			if err != nil {
+				utils.PrintlnInfo(fmt.Sprintf("Failed to delete environment: %v", err))
+			}
 		}
+	}
 
-		// Cleanup role and token
-		rdeCleanupRoleAndToken(client, orgId, rdeName)
+	// Delete project
+	utils.Println(fmt.Sprintf("  Deleting project %s...", pterm.FgBlue.Sprintf("%s", projectName)))
+	_, err = client.ProjectMainCallsAPI.DeleteProject(ctx(), project.Id).Execute()
+	if err != nil {
+		// the role and token are kept, the RDE still exists
+		return fmt.Errorf("failed to delete project %s: %w", projectName, err)
+	}
 
-		utils.Println(fmt.Sprintf("\nRDE %s fully removed.", pterm.FgBlue.Sprintf("%s", rdeName)))
-	},
+	// Cleanup role and token
+	rdeCleanupRoleAndToken(client, orgId, name)
+
+	return nil
 }
 
 // rdeCleanupRoleAndToken removes the RBAC role and API token associated with an RDE.
@@ -0,0 +1,443 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+)
+
+// Categories of drift, matching the sync options of 'rde upgrade'
+const (
+	rdeDriftService      = "service"
+	rdeDriftSource       = "source"
+	rdeDriftResources    = "resources"
+	rdeDriftPorts        = "ports"
+	rdeDriftHealthchecks = "healthchecks"
+	rdeDriftStorage      = "storage"
+)
+
+// rdeDriftMaxValueLength is the maximum length of the values printed in the drift table
+const rdeDriftMaxValueLength = 60
+
+var rdeDriftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Show the differences between RDE(s) and their blueprint",
+	Long: `Show the differences between RDE(s) and their blueprint.
+
+Services are matched by name and compared on the fields synced by 'qovery rde upgrade --strategy image':
+  source        - image, tag, git repository, branch, chart (always synced)
+  resources     - CPU, memory, instances, autoscaling (--sync-resources)
+  ports         - port configuration (--sync-ports)
+  healthchecks  - health checks (--sync-healthchecks)
+  storage       - storage volumes (--sync-storage)
+Services only present on one side are reported as well, they are not changed by an upgrade.
+
+If --name is provided, compares a single RDE. Otherwise, compares all RDEs.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		children, err := rdeSelectChildren(client, orgId, rdeName, rdeBlueprintProjectName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		if len(children) == 0 {
+			utils.Println("No RDE instances found.")
+			return
+		}
+
+		if jsonFlag {
+			results := make(map[string][]rdeDrift)
+			for _, child := range children {
+				drifts, err := rdeComputeChildDrift(client, &child)
+				checkError(err)
+				results[child.ProjectName] = drifts
+			}
+			j, err := json.Marshal(results)
+			checkError(err)
+			utils.Println(string(j))
+			return
+		}
+
+		rdePrintDriftReport(client, children, nil)
+	},
+}
+
+func init() {
+	rdeCmd.AddCommand(rdeDriftCmd)
+	rdeDriftCmd.Flags().StringVarP(&rdeName, "name", "n", "", "RDE Name (omit to compare all)")
+	rdeDriftCmd.Flags().StringVarP(&rdeBlueprintProjectName, "blueprint", "b", "", "Filter by Blueprint Project Name (when comparing all)")
+	rdeDriftCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeDriftCmd.Flags().BoolVarP(&jsonFlag, "json", "", false, "JSON output")
+}
+
+// rdeDrift is a field of a service of an RDE whose value differs from the blueprint.
+type rdeDrift struct {
+	Service   string `json:"service"`
+	Type      string `json:"type"`
+	Category  string `json:"category"`
+	Field     string `json:"field"`
+	Current   string `json:"current"`
+	Blueprint string `json:"blueprint"`
+}
+
+// rdeSelectChildren returns the RDE name, or all the RDEs of the blueprint, or all the RDEs of the organization.
+func rdeSelectChildren(client *qovery.APIClient, orgId string, name string, blueprintProjectName string) ([]rdeChildInfo, error) {
+	if name != "" {
+		child, err := rdeFindChildByName(client, orgId, fmt.Sprintf("rde-%s", name))
+		if err != nil {
+			return nil, err
+		}
+		return []rdeChildInfo{*child}, nil
+	}
+
+	if blueprintProjectName != "" {
+		bp, err := rdeFindBlueprintByProjectName(client, orgId, blueprintProjectName)
+		if err != nil {
+			return nil, err
+		}
+		return rdeListChildren(client, orgId, bp.ProjectId)
+	}
+
+	return rdeListAllChildren(client, orgId)
+}
+
+// rdeDriftValue formats a value of a service for comparison. Identifiers are ignored, as they differ between clones.
+func rdeDriftValue(v interface{}) string {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(content, &value); err != nil {
+		return string(content)
+	}
+	value = rdeDriftStripIds(value)
+
+	switch value := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		return value
+	default:
+		content, _ = json.Marshal(value)
+		return string(content)
+	}
+}
+
+func rdeDriftStripIds(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		delete(v, "id")
+		for key, value := range v {
+			v[key] = rdeDriftStripIds(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = rdeDriftStripIds(value)
+		}
+	}
+	return v
+}
+
+// rdeDriftBuilder collects the fields of a service differing from the blueprint.
+type rdeDriftBuilder struct {
+	service     string
+	serviceType utils.ServiceType
+	drifts      []rdeDrift
+}
+
+func (b *rdeDriftBuilder) compare(category string, field string, current interface{}, blueprint interface{}) {
+	currentValue := rdeDriftValue(current)
+	blueprintValue := rdeDriftValue(blueprint)
+	if currentValue != blueprintValue {
+		b.drifts = append(b.drifts, rdeDrift{
+			Service:   b.service,
+			Type:      string(b.serviceType),
+			Category:  category,
+			Field:     field,
+			Current:   currentValue,
+			Blueprint: blueprintValue,
+		})
+	}
+}
+
+// rdeContainerDrift compares the fields of a container synced from the blueprint.
+func rdeContainerDrift(child qovery.ContainerResponse, bp qovery.ContainerResponse) []rdeDrift {
+	b := rdeDriftBuilder{service: child.Name, serviceType: utils.ContainerType}
+	b.compare(rdeDriftSource, "registry", child.Registry.Id, bp.Registry.Id)
+	b.compare(rdeDriftSource, "image", child.ImageName, bp.ImageName)
+	b.compare(rdeDriftSource, "tag", child.Tag, bp.Tag)
+	b.compare(rdeDriftResources, "cpu", child.Cpu, bp.Cpu)
+	b.compare(rdeDriftResources, "memory", child.Memory, bp.Memory)
+	b.compare(rdeDriftResources, "min_running_instances", child.MinRunningInstances, bp.MinRunningInstances)
+	b.compare(rdeDriftResources, "max_running_instances", child.MaxRunningInstances, bp.MaxRunningInstances)
+	b.compare(rdeDriftResources, "autoscaling", utils.ConvertAutoscalingResponseToRequest(child.Autoscaling), utils.ConvertAutoscalingResponseToRequest(bp.Autoscaling))
+	b.compare(rdeDriftPorts, "ports", child.Ports, bp.Ports)
+	b.compare(rdeDriftHealthchecks, "healthchecks", child.Healthchecks, bp.Healthchecks)
+	b.compare(rdeDriftStorage, "storage", child.Storage, bp.Storage)
+	return b.drifts
+}
+
+// rdeApplicationDrift compares the fields of an application synced from the blueprint.
+func rdeApplicationDrift(child qovery.Application, bp qovery.Application) []rdeDrift {
+	gitRepository := func(a qovery.Application) (url interface{}, branch interface{}, rootPath interface{}) {
+		if a.GitRepository == nil {
+			return nil, nil, nil
+		}
+		return a.GitRepository.Url, a.GitRepository.Branch, a.GitRepository.RootPath
+	}
+	childUrl, childBranch, childRootPath := gitRepository(child)
+	bpUrl, bpBranch, bpRootPath := gitRepository(bp)
+
+	b := rdeDriftBuilder{service: child.Name, serviceType: utils.ApplicationType}
+	b.compare(rdeDriftSource, "git_repository", childUrl, bpUrl)
+	b.compare(rdeDriftSource, "branch", childBranch, bpBranch)
+	b.compare(rdeDriftSource, "root_path", childRootPath, bpRootPath)
+	b.compare(rdeDriftSource, "build_mode", child.BuildMode, bp.BuildMode)
+	b.compare(rdeDriftSource, "dockerfile_path", child.DockerfilePath, bp.DockerfilePath)
+	b.compare(rdeDriftResources, "cpu", child.Cpu, bp.Cpu)
+	b.compare(rdeDriftResources, "memory", child.Memory, bp.Memory)
+	b.compare(rdeDriftResources, "min_running_instances", child.MinRunningInstances, bp.MinRunningInstances)
+	b.compare(rdeDriftResources, "max_running_instances", child.MaxRunningInstances, bp.MaxRunningInstances)
+	b.compare(rdeDriftPorts, "ports", child.Ports, bp.Ports)
+	b.compare(rdeDriftHealthchecks, "healthchecks", child.Healthchecks, bp.Healthchecks)
+	b.compare(rdeDriftStorage, "storage", child.Storage, bp.Storage)
+	return b.drifts
+}
+
+// rdeJobDrift compares the fields of a job synced from the blueprint.
+func rdeJobDrift(child qovery.JobResponse, bp qovery.JobResponse) []rdeDrift {
+	b := rdeDriftBuilder{service: utils.GetJobName(&child), serviceType: utils.JobType}
+	b.compare(rdeDriftSource, "source", rdeJobResponseToRequestSource(&child), rdeJobResponseToRequestSource(&bp))
+
+	childDetail := rdeExtractJobDetail(&child)
+	bpDetail := rdeExtractJobDetail(&bp)
+	if childDetail != nil && bpDetail != nil {
+		b.compare(rdeDriftResources, "cpu", childDetail.cpu, bpDetail.cpu)
+		b.compare(rdeDriftResources, "memory", childDetail.memory, bpDetail.memory)
+		b.compare(rdeDriftHealthchecks, "healthchecks", childDetail.healthchecks, bpDetail.healthchecks)
+	}
+	return b.drifts
+}
+
+// rdeHelmDrift compares the fields of a helm synced from the blueprint.
+func rdeHelmDrift(child qovery.HelmResponse, bp qovery.HelmResponse) []rdeDrift {
+	b := rdeDriftBuilder{service: child.Name, serviceType: utils.HelmType}
+	b.compare(rdeDriftSource, "source", rdeConvertHelmSource(&child.Source), rdeConvertHelmSource(&bp.Source))
+	return b.drifts
+}
+
+// rdeServicePresenceDrift reports the services present only in the RDE or only in the blueprint.
+func rdeServicePresenceDrift(serviceType utils.ServiceType, child []string, blueprint []string) []rdeDrift {
+	inChild := make(map[string]bool)
+	for _, name := range child {
+		inChild[name] = true
+	}
+	inBlueprint := make(map[string]bool)
+	for _, name := range blueprint {
+		inBlueprint[name] = true
+	}
+
+	var drifts []rdeDrift
+	for _, name := range blueprint {
+		if !inChild[name] {
+			drifts = append(drifts, rdeDrift{Service: name, Type: string(serviceType), Category: rdeDriftService, Field: "service", Current: "missing", Blueprint: "present"})
+		}
+	}
+	for _, name := range child {
+		if !inBlueprint[name] {
+			drifts = append(drifts, rdeDrift{Service: name, Type: string(serviceType), Category: rdeDriftService, Field: "service", Current: "present", Blueprint: "missing"})
+		}
+	}
+	return drifts
+}
+
+// rdeComputeDrift compares the services of the child environment to the ones of the blueprint environment.
+func rdeComputeDrift(client *qovery.APIClient, blueprintEnvId string, childEnvId string) ([]rdeDrift, error) {
+	var drifts []rdeDrift
+
+	bpContainers, _, err := client.ContainersAPI.ListContainer(ctx(), blueprintEnvId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	childContainers, _, err := client.ContainersAPI.ListContainer(ctx(), childEnvId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	bpContainerMap := make(map[string]qovery.ContainerResponse)
+	var bpNames, childNames []string
+	for _, c := range bpContainers.GetResults() {
+		bpContainerMap[c.Name] = c
+		bpNames = append(bpNames, c.Name)
+	}
+	for _, c := range childContainers.GetResults() {
+		childNames = append(childNames, c.Name)
+		if bp, ok := bpContainerMap[c.Name]; ok {
+			drifts = append(drifts, rdeContainerDrift(c, bp)...)
+		}
+	}
+	drifts = append(drifts, rdeServicePresenceDrift(utils.ContainerType, childNames, bpNames)...)
+
+	bpApps, _, err := client.ApplicationsAPI.ListApplication(ctx(), blueprintEnvId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	childApps, _, err := client.ApplicationsAPI.ListApplication(ctx(), childEnvId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	bpAppMap := make(map[string]qovery.Application)
+	bpNames, childNames = nil, nil
+	for _, a := range bpApps.GetResults() {
+		bpAppMap[a.Name] = a
+		bpNames = append(bpNames, a.Name)
+	}
+	for _, a := range childApps.GetResults() {
+		childNames = append(childNames, a.Name)
+		if bp, ok := bpAppMap[a.Name]; ok {
+			drifts = append(drifts, rdeApplicationDrift(a, bp)...)
+		}
+	}
+	drifts = append(drifts, rdeServicePresenceDrift(utils.ApplicationType, childNames, bpNames)...)
+
+	bpJobs, _, err := client.JobsAPI.ListJobs(ctx(), blueprintEnvId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	childJobs, _, err := client.JobsAPI.ListJobs(ctx(), childEnvId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	bpJobMap := make(map[string]qovery.JobResponse)
+	bpNames, childNames = nil, nil
+	for _, j := range bpJobs.GetResults() {
+		name := utils.GetJobName(&j)
+		bpJobMap[name] = j
+		bpNames = append(bpNames, name)
+	}
+	for _, j := range childJobs.GetResults() {
+		name := utils.GetJobName(&j)
+		childNames = append(childNames, name)
+		if bp, ok := bpJobMap[name]; ok {
+			drifts = append(drifts, rdeJobDrift(j, bp)...)
+		}
+	}
+	drifts = append(drifts, rdeServicePresenceDrift(utils.JobType, childNames, bpNames)...)
+
+	bpHelms, _, err := client.HelmsAPI.ListHelms(ctx(), blueprintEnvId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	childHelms, _, err := client.HelmsAPI.ListHelms(ctx(), childEnvId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	bpHelmMap := make(map[string]qovery.HelmResponse)
+	bpNames, childNames = nil, nil
+	for _, h := range bpHelms.GetResults() {
+		bpHelmMap[h.Name] = h
+		bpNames = append(bpNames, h.Name)
+	}
+	for _, h := range childHelms.GetResults() {
+		childNames = append(childNames, h.Name)
+		if bp, ok := bpHelmMap[h.Name]; ok {
+			drifts = append(drifts, rdeHelmDrift(h, bp)...)
+		}
+	}
+	drifts = append(drifts, rdeServicePresenceDrift(utils.HelmType, childNames, bpNames)...)
+
+	sort.SliceStable(drifts, func(i, j int) bool {
+		if drifts[i].Service != drifts[j].Service {
+			return drifts[i].Service < drifts[j].Service
+		}
+		return drifts[i].Type < drifts[j].Type
+	})
+	return drifts, nil
+}
+
+// rdeComputeChildDrift compares the services of an RDE to the ones of its blueprint.
+func rdeComputeChildDrift(client *qovery.APIClient, child *rdeChildInfo) ([]rdeDrift, error) {
+	bpEnv, err := rdeFindBlueprintEnv(client, child.BlueprintProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if bpEnv == nil {
+		return nil, fmt.Errorf("no blueprint environment found for %s", child.ProjectName)
+	}
+	return rdeComputeDrift(client, bpEnv.EnvId, child.EnvId)
+}
+
+// rdeFilterDrift returns the drifts of the categories, or all of them when categories is nil.
+func rdeFilterDrift(drifts []rdeDrift, categories map[string]bool) []rdeDrift {
+	if categories == nil {
+		return drifts
+	}
+	var filtered []rdeDrift
+	for _, drift := range drifts {
+		if categories[drift.Category] {
+			filtered = append(filtered, drift)
+		}
+	}
+	return filtered
+}
+
+// rdeSyncDriftCategories returns the categories of drift changed by 'rde upgrade --strategy image' with the sync flags.
+func rdeSyncDriftCategories(all bool, resources bool, ports bool, healthchecks bool, storage bool) map[string]bool {
+	return map[string]bool{
+		rdeDriftSource:       true,
+		rdeDriftResources:    all || resources,
+		rdeDriftPorts:        all || ports,
+		rdeDriftHealthchecks: all || healthchecks,
+		rdeDriftStorage:      all || storage,
+	}
+}
+
+func rdeTruncateDriftValue(value string) string {
+	if len(value) <= rdeDriftMaxValueLength {
+		return value
+	}
+	return value[:rdeDriftMaxValueLength-3] + "..."
+}
+
+// rdePrintDriftReport prints the drifts of the categories (all of them when categories is nil) of each RDE.
+func rdePrintDriftReport(client *qovery.APIClient, children []rdeChildInfo, categories map[string]bool) {
+	for _, child := range children {
+		name := strings.TrimPrefix(child.ProjectName, "rde-")
+
+		drifts, err := rdeComputeChildDrift(client, &child)
+		if err != nil {
+			utils.Println(fmt.Sprintf("%s: ERROR: %v", pterm.FgBlue.Sprintf("%s", name), err))
+			continue
+		}
+
+		drifts = rdeFilterDrift(drifts, categories)
+		if len(drifts) == 0 {
+			utils.Println(fmt.Sprintf("%s: in sync with its blueprint", pterm.FgBlue.Sprintf("%s", name)))
+			continue
+		}
+
+		utils.Println(fmt.Sprintf("%s: %d difference(s) with its blueprint", pterm.FgBlue.Sprintf("%s", name), len(drifts)))
+		var data [][]string
+		for _, drift := range drifts {
+			data = append(data, []string{drift.Service, drift.Type, drift.Category, drift.Field, rdeTruncateDriftValue(drift.Current), rdeTruncateDriftValue(drift.Blueprint)})
+		}
+		err = utils.PrintTable([]string{"Service", "Type", "Category", "Field", "Current", "Blueprint"}, data)
+		checkError(err)
+		utils.Println("")
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+func TestRdeDriftValue(t *testing.T) {
+	branch := "main"
+	var missing *string
+
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{"v1.2.3", "v1.2.3"},
+		{&branch, "main"},
+		{missing, "-"},
+		{int32(500), "500"},
+		{[]map[string]interface{}{{"id": "1f0e", "internal_port": 8080, "publicly_accessible": true}}, `[{"internal_port":8080,"publicly_accessible":true}]`},
+	}
+
+	for _, tt := range tests {
+		if value := rdeDriftValue(tt.value); value != tt.expected {
+			t.Errorf("rdeDriftValue(%v) = %q, expected %q", tt.value, value, tt.expected)
+		}
+	}
+}
+
+func TestRdeServicePresenceDrift(t *testing.T) {
+	drifts := rdeServicePresenceDrift(utils.ContainerType, []string{"api", "scratch"}, []string{"api", "worker"})
+
+	expected := []rdeDrift{
+		{Service: "worker", Type: "container", Category: rdeDriftService, Field: "service", Current: "missing", Blueprint: "present"},
+		{Service: "scratch", Type: "container", Category: rdeDriftService, Field: "service", Current: "present", Blueprint: "missing"},
+	}
+	if !reflect.DeepEqual(drifts, expected) {
+		t.Errorf("unexpected drifts %v", drifts)
+	}
+}
+
+func TestRdeFilterDrift(t *testing.T) {
+	drifts := []rdeDrift{
+		{Service: "api", Category: rdeDriftSource, Field: "tag"},
+		{Service: "api", Category: rdeDriftResources, Field: "cpu"},
+		{Service: "api", Category: rdeDriftPorts, Field: "ports"},
+		{Service: "worker", Category: rdeDriftService, Field: "service"},
+	}
+
+	fields := func(drifts []rdeDrift) []string {
+		var fields []string
+		for _, drift := range drifts {
+			fields = append(fields, drift.Field)
+		}
+		return fields
+	}
+
+	tests := []struct {
+		categories map[string]bool
+		expected   []string
+	}{
+		{nil, []string{"tag", "cpu", "ports", "service"}},
+		{rdeSyncDriftCategories(false, false, false, false, false), []string{"tag"}},
+		{rdeSyncDriftCategories(false, true, false, false, false), []string{"tag", "cpu"}},
+		{rdeSyncDriftCategories(true, false, false, false, false), []string{"tag", "cpu", "ports"}},
+	}
+
+	for _, tt := range tests {
+		if filtered := fields(rdeFilterDrift(drifts, tt.categories)); !reflect.DeepEqual(filtered, tt.expected) {
+			t.Errorf("rdeFilterDrift(%v) = %v, expected %v", tt.categories, filtered, tt.expected)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/qovery/qovery-cli/utils"
 	"github.com/qovery/qovery-client-go"
@@ -15,7 +16,7 @@ var rdeListCmd = &cobra.Command{
 	Short: "List all RDE instances",
 	Long: `List all Remote Development Environments, optionally filtered by blueprint.
 
-Shows name, blueprint, status, uptime, and workspace URL for each RDE.`,
+Shows name, blueprint, blueprint version, status, uptime, remaining time-to-live, owner, and workspace URL for each RDE.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Capture(cmd)
 
@@ -61,16 +62,30 @@ Shows name, blueprint, status, uptime, and workspace URL for each RDE.`,
 					}
 				}
 				bpName := rdeBlueprintNameForProjectId(client, child.BlueprintProjectId)
+				var expiresAt interface{}
+				stopAfterDeploy := ""
+				policy, err := rdeGetTTLPolicy(client, child.ProjectId)
+				if err == nil {
+					if policy.ExpiresAt != nil {
+						expiresAt = utils.ToIso8601(policy.ExpiresAt)
+					}
+					if policy.StopAfterDeploy > 0 {
+						stopAfterDeploy = policy.StopAfterDeploy.String()
+					}
+				}
 				results = append(results, map[string]interface{}{
-					"project_id":     child.ProjectId,
-					"project_name":   child.ProjectName,
-					"env_id":         child.EnvId,
-					"env_name":       child.EnvName,
-					"blueprint_id":   child.BlueprintProjectId,
-					"blueprint_name": bpName,
-					"status":         status,
-					"owner":          child.OwnerEmail,
-					"workspace_url":  url,
+					"project_id":        child.ProjectId,
+					"project_name":      child.ProjectName,
+					"env_id":            child.EnvId,
+					"env_name":          child.EnvName,
+					"blueprint_id":      child.BlueprintProjectId,
+					"blueprint_name":    bpName,
+					"blueprint_version": rdeFormatBlueprintVersion(child.BlueprintVersion),
+					"status":            status,
+					"owner":             child.OwnerEmail,
+					"workspace_url":     url,
+					"expires_at":        expiresAt,
+					"stop_after_deploy": stopAfterDeploy,
 				})
 			}
 			j, _ := json.Marshal(results)
@@ -117,10 +132,16 @@ Shows name, blueprint, status, uptime, and workspace URL for each RDE.`,
 				owner = "-"
 			}
 
-			data = append(data, []string{child.ProjectName, bpName, status, uptime, owner, url})
+			ttl := "-"
+			policy, err := rdeGetTTLPolicy(client, child.ProjectId)
+			if err == nil {
+				ttl = rdeFormatRemaining(policy, time.Now())
+			}
+
+			data = append(data, []string{child.ProjectName, bpName, rdeFormatBlueprintVersion(child.BlueprintVersion), status, uptime, ttl, owner, url})
 		}
 
-		err = utils.PrintTable([]string{"Name", "Blueprint", "Status", "Uptime", "Owner", "Workspace URL"}, data)
+		err = utils.PrintTable([]string{"Name", "Blueprint", "Version", "Status", "Uptime", "TTL", "Owner", "Workspace URL"}, data)
 		if err != nil {
 			utils.PrintlnError(err)
 			os.Exit(1)
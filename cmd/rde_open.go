@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/browser"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+// RDE open flag variables
+var rdeOpenVSCode bool
+var rdeOpenPath string
+var rdeOpenPrint bool
+var rdeOpenSSHConfig string
+
+var rdeOpenCmd = &cobra.Command{
+	Use:   "open",
+	Short: "Open the workspace of an RDE in the browser or in VS Code",
+	Long: `Open the workspace of an RDE in the browser or in VS Code.
+
+By default, the workspace URL of the RDE is opened in the browser.
+With --vscode, VS Code is opened on --path of the RDE with the Remote - SSH extension,
+using the SSH entry written by 'qovery rde connect --ssh'. When that entry was written to another file with --ssh-config,
+pass the same --ssh-config, and set it as the 'remote.SSH.configFile' setting of VS Code.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		var url string
+		if rdeOpenVSCode {
+			host := rdeSSHHost(rdeName)
+			path, err := rdeSSHConfigPath(rdeOpenSSHConfig)
+			checkError(err)
+			content, err := os.ReadFile(path)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				checkError(err)
+			}
+			if !strings.Contains(string(content), "Host "+host+"\n") {
+				utils.PrintlnError(fmt.Errorf("no SSH entry %s in %s, run 'qovery rde connect --ssh --name %s' first, with the same --ssh-config", host, path, rdeName))
+				os.Exit(1)
+				panic("unreachable")
+			}
+			url = rdeVSCodeURI(host, rdeOpenPath)
+		} else {
+			client := utils.GetQoveryClientPanicInCaseOfError()
+			orgId, err := rdeGetOrgId(client)
+			checkError(err)
+
+			child, err := rdeFindChildByName(client, orgId, fmt.Sprintf("rde-%s", rdeName))
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable")
+			}
+
+			url = rdeGetWorkspaceUrl(client, child.EnvId)
+			if url == "" {
+				utils.PrintlnError(fmt.Errorf("no workspace URL found for %s, is it running?", child.ProjectName))
+				os.Exit(1)
+				panic("unreachable")
+			}
+		}
+
+		if rdeOpenPrint {
+			utils.Println(url)
+			return
+		}
+
+		utils.PrintlnInfo("Opening " + url)
+		err := browser.OpenURL(url)
+		checkError(err)
+	},
+}
+
+func init() {
+	rdeCmd.AddCommand(rdeOpenCmd)
+	rdeOpenCmd.Flags().StringVarP(&rdeName, "name", "n", "", "RDE Name")
+	rdeOpenCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeOpenCmd.Flags().BoolVarP(&rdeOpenVSCode, "vscode", "", false, "Open the RDE in VS Code over SSH")
+	rdeOpenCmd.Flags().StringVarP(&rdeOpenPath, "path", "", "/", "Folder of the RDE to open in VS Code")
+	rdeOpenCmd.Flags().BoolVarP(&rdeOpenPrint, "print", "", false, "Print the URL instead of opening it")
+	rdeOpenCmd.Flags().StringVarP(&rdeOpenSSHConfig, "ssh-config", "", "", "SSH config file holding the entry of the RDE, with --vscode (default ~/.ssh/config)")
+
+	_ = rdeOpenCmd.MarkFlagRequired("name")
+}
+
+// rdeVSCodeURI returns the URI opening path of the SSH host in VS Code.
+func rdeVSCodeURI(host string, path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return fmt.Sprintf("vscode://vscode-remote/ssh-remote+%s%s", host, path)
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+)
+
+// rdeSettingsProjectName is the project holding the organization-wide RDE settings as project variables
+const rdeSettingsProjectName = "qovery-rde-settings"
+
+// RDE quota project variables
+const rdeQuotaMaxPerOwnerVar = "RDE_QUOTA_MAX_PER_OWNER"
+const rdeQuotaMaxRunningVar = "RDE_QUOTA_MAX_RUNNING"
+
+// RDE quota flag variables
+var rdeQuotaMaxPerOwner int
+var rdeQuotaMaxRunning int
+
+var rdeQuotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: "Manage the RDE quotas of the organization",
+	Long: `Manage the RDE quotas of the organization.
+
+The quotas apply to each developer, identified by the owner email of the RDEs:
+  - the maximum number of RDEs, enforced by 'qovery rde create'
+  - the maximum number of running RDEs, enforced by 'qovery rde create' and 'qovery rde start'
+
+When quotas are configured, 'qovery rde create' requires --email. RDEs without owner email are not limited.
+
+They are stored as project variables of the ` + rdeSettingsProjectName + ` project. 0 means unlimited.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if len(args) == 0 {
+			_ = cmd.Help()
+			os.Exit(0)
+		}
+	},
+}
+
+func init() {
+	rdeCmd.AddCommand(rdeQuotaCmd)
+}
+
+// rdeQuota is the RDE quotas of an organization, 0 means unlimited.
+type rdeQuota struct {
+	MaxPerOwner int
+	MaxRunning  int
+}
+
+// checkCreate returns an error when an owner with owned RDEs, running of them, cannot create another one.
+func (quota rdeQuota) checkCreate(owner string, owned int, running int, deploy bool) error {
+	if quota.MaxPerOwner > 0 && owned >= quota.MaxPerOwner {
+		return fmt.Errorf("%s already owns %d RDE(s), the quota is %d per developer", owner, owned, quota.MaxPerOwner)
+	}
+	if deploy {
+		return quota.checkStart(owner, running)
+	}
+	return nil
+}
+
+// checkStart returns an error when an owner with running RDEs cannot start another one.
+func (quota rdeQuota) checkStart(owner string, running int) error {
+	if quota.MaxRunning > 0 && running >= quota.MaxRunning {
+		return fmt.Errorf("%s already has %d running RDE(s), the quota is %d per developer", owner, running, quota.MaxRunning)
+	}
+	return nil
+}
+
+// rdeFindSettingsProject returns the project holding the RDE settings of the organization, or nil when it does not exist.
+func rdeFindSettingsProject(client *qovery.APIClient, orgId string) (*qovery.Project, error) {
+	projects, _, err := client.ProjectsAPI.ListProject(ctx(), orgId).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects.GetResults() {
+		if project.Name == rdeSettingsProjectName {
+			return &project, nil
+		}
+	}
+	return nil, nil
+}
+
+// rdeGetQuota returns the RDE quotas of the organization.
+func rdeGetQuota(client *qovery.APIClient, orgId string) (rdeQuota, error) {
+	var quota rdeQuota
+
+	project, err := rdeFindSettingsProject(client, orgId)
+	if err != nil || project == nil {
+		return quota, err
+	}
+
+	vars, err := utils.ListProjectVariables(client, project.Id)
+	if err != nil {
+		return quota, err
+	}
+
+	value := func(key string) (int, error) {
+		v := utils.FindEnvironmentVariableByKey(key, vars)
+		if v == nil || !v.Value.IsSet() || v.Value.Get() == nil {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(*v.Value.Get())
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %s: %w", key, *v.Value.Get(), err)
+		}
+		return n, nil
+	}
+
+	if quota.MaxPerOwner, err = value(rdeQuotaMaxPerOwnerVar); err != nil {
+		return quota, err
+	}
+	if quota.MaxRunning, err = value(rdeQuotaMaxRunningVar); err != nil {
+		return quota, err
+	}
+	return quota, nil
+}
+
+// rdeSetQuota records a quota of the organization, creating the settings project if needed. A quota of 0 removes it.
+func rdeSetQuota(client *qovery.APIClient, orgId string, key string, value int) error {
+	project, err := rdeFindSettingsProject(client, orgId)
+	if err != nil {
+		return err
+	}
+
+	if project == nil {
+		if value == 0 {
+			return nil
+		}
+		desc := "Organization-wide settings of the RDEs"
+		projectReq := qovery.NewProjectRequest(rdeSettingsProjectName)
+		projectReq.Description = &desc
+		project, _, err = client.ProjectsAPI.CreateProject(ctx(), orgId).ProjectRequest(*projectReq).Execute()
+		if err != nil {
+			return err
+		}
+	}
+
+	if value == 0 {
+		_ = utils.DeleteProjectVar(client, project.Id, key)
+		return nil
+	}
+	return rdeSetProjectVariable(client, project.Id, key, strconv.Itoa(value))
+}
+
+// rdeIsRunningState returns whether an RDE in state counts as running: every state but the stopped, deleted and error ones,
+// so that the RDEs queued or being built count too.
+func rdeIsRunningState(state qovery.StateEnum) bool {
+	if state == "" || strings.HasSuffix(string(state), "ERROR") {
+		return false
+	}
+	return state != qovery.STATEENUM_STOPPED && state != qovery.STATEENUM_STOPPING && state != qovery.STATEENUM_DELETED
+}
+
+// rdeCountOwnerRDEs returns the number of RDEs of the owner among children, and how many of them are running.
+// The RDE of the project excludeProjectId is not counted.
+func rdeCountOwnerRDEs(client *qovery.APIClient, children []rdeChildInfo, owner string, excludeProjectId string) (int, int) {
+	owned := 0
+	running := 0
+	for _, child := range children {
+		if !strings.EqualFold(child.OwnerEmail, owner) || child.ProjectId == excludeProjectId {
+			continue
+		}
+		owned++
+		if child.EnvId == "" {
+			continue
+		}
+		if state, err := rdeGetEnvStatus(client, child.EnvId); err == nil && rdeIsRunningState(state) {
+			running++
+		}
+	}
+	return owned, running
+}
+
+// rdeEnforceQuota exits with an error when the owner would exceed the quotas of the organization by creating
+// (create is set) or starting an RDE. The RDE of the project excludeProjectId is not counted.
+// Creating an RDE without owner is refused when quotas are configured, starting one is not limited.
+func rdeEnforceQuota(client *qovery.APIClient, orgId string, owner string, create bool, deploy bool, excludeProjectId string) {
+	quota, err := rdeGetQuota(client, orgId)
+	checkError(err)
+	if quota.MaxPerOwner == 0 && quota.MaxRunning == 0 {
+		return
+	}
+
+	if owner == "" {
+		if !create {
+			return
+		}
+		utils.PrintlnError(fmt.Errorf("RDE quotas are configured, --email is required to identify the owner of the RDE"))
+		os.Exit(1)
+		panic("unreachable")
+	}
+
+	children, err := rdeListAllChildren(client, orgId)
+	checkError(err)
+	owned, running := rdeCountOwnerRDEs(client, children, owner, excludeProjectId)
+
+	if create {
+		err = quota.checkCreate(owner, owned, running, deploy)
+	} else {
+		err = quota.checkStart(owner, running)
+	}
+	if err != nil {
+		utils.PrintlnError(err)
+		os.Exit(1)
+		panic("unreachable")
+	}
+}
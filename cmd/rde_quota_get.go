@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var rdeQuotaGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the RDE quotas of the organization",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		quota, err := rdeGetQuota(client, orgId)
+		checkError(err)
+
+		rdePrintKeyValueTable([][]string{
+			{"Max RDEs Per Developer", rdeFormatQuota(quota.MaxPerOwner)},
+			{"Max Running RDEs Per Developer", rdeFormatQuota(quota.MaxRunning)},
+		})
+	},
+}
+
+func init() {
+	rdeQuotaCmd.AddCommand(rdeQuotaGetCmd)
+	rdeQuotaGetCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+}
+
+// rdeFormatQuota returns the quota max for display.
+func rdeFormatQuota(max int) string {
+	if max <= 0 {
+		return "unlimited"
+	}
+	return strconv.Itoa(max)
+}
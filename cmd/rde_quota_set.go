@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var rdeQuotaSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set the RDE quotas of the organization",
+	Long: `Set the maximum number of RDEs and/or of running RDEs per developer.
+
+Use 0 to remove a quota.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		setMaxPerOwner := cmd.Flags().Changed("max-per-owner")
+		setMaxRunning := cmd.Flags().Changed("max-running")
+		if !setMaxPerOwner && !setMaxRunning {
+			utils.PrintlnError(fmt.Errorf("--max-per-owner or --max-running is required"))
+			os.Exit(1)
+			panic("unreachable")
+		}
+		if rdeQuotaMaxPerOwner < 0 || rdeQuotaMaxRunning < 0 {
+			utils.PrintlnError(fmt.Errorf("quotas cannot be negative"))
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		if setMaxPerOwner {
+			err = rdeSetQuota(client, orgId, rdeQuotaMaxPerOwnerVar, rdeQuotaMaxPerOwner)
+			checkError(err)
+		}
+		if setMaxRunning {
+			err = rdeSetQuota(client, orgId, rdeQuotaMaxRunningVar, rdeQuotaMaxRunning)
+			checkError(err)
+		}
+
+		utils.Println("RDE quotas have been updated")
+	},
+}
+
+func init() {
+	rdeQuotaCmd.AddCommand(rdeQuotaSetCmd)
+	rdeQuotaSetCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeQuotaSetCmd.Flags().IntVarP(&rdeQuotaMaxPerOwner, "max-per-owner", "", 0, "Maximum number of RDEs per developer (0 for unlimited)")
+	rdeQuotaSetCmd.Flags().IntVarP(&rdeQuotaMaxRunning, "max-running", "", 0, "Maximum number of running RDEs per developer (0 for unlimited)")
+	rdeQuotaSetCmd.Example = "qovery rde quota set --max-per-owner 3 --max-running 1"
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/qovery/qovery-client-go"
+)
+
+func TestRdeQuotaCheck(t *testing.T) {
+	tests := []struct {
+		quota   rdeQuota
+		owned   int
+		running int
+		deploy  bool
+		create  bool
+		start   bool
+	}{
+		{quota: rdeQuota{}, owned: 10, running: 10, deploy: true, create: true, start: true},
+		{quota: rdeQuota{MaxPerOwner: 2}, owned: 1, running: 1, deploy: true, create: true, start: true},
+		{quota: rdeQuota{MaxPerOwner: 2}, owned: 2, running: 0, deploy: true, create: false, start: true},
+		{quota: rdeQuota{MaxRunning: 1}, owned: 3, running: 1, deploy: true, create: false, start: false},
+		{quota: rdeQuota{MaxRunning: 1}, owned: 3, running: 1, deploy: false, create: true, start: false},
+		{quota: rdeQuota{MaxPerOwner: 5, MaxRunning: 2}, owned: 4, running: 1, deploy: true, create: true, start: true},
+	}
+
+	for i, tt := range tests {
+		if err := tt.quota.checkCreate("alice@example.com", tt.owned, tt.running, tt.deploy); (err == nil) != tt.create {
+			t.Errorf("case %d: unexpected create check result %v", i, err)
+		}
+		if err := tt.quota.checkStart("alice@example.com", tt.running); (err == nil) != tt.start {
+			t.Errorf("case %d: unexpected start check result %v", i, err)
+		}
+	}
+}
+
+func TestRdeIsRunningState(t *testing.T) {
+	tests := []struct {
+		state    qovery.StateEnum
+		expected bool
+	}{
+		{qovery.STATEENUM_DEPLOYED, true},
+		{qovery.STATEENUM_QUEUED, true},
+		{qovery.STATEENUM_BUILDING, true},
+		{qovery.STATEENUM_DEPLOYMENT_QUEUED, true},
+		{qovery.STATEENUM_STOPPED, false},
+		{qovery.STATEENUM_STOPPING, false},
+		{qovery.STATEENUM_DELETED, false},
+		{qovery.STATEENUM_DEPLOYMENT_ERROR, false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if running := rdeIsRunningState(tt.state); running != tt.expected {
+			t.Errorf("rdeIsRunningState(%q) = %v, expected %v", tt.state, running, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var rdeReapDeleteExpired bool
+var rdeReapDryRun bool
+
+var rdeReapCmd = &cobra.Command{
+	Use:   "reap",
+	Short: "Stop or delete expired RDEs and RDEs running for too long since their last deployment",
+	Long: `Apply the TTL policy of every RDE of the organization (see 'qovery rde ttl'):
+
+  - expired RDEs are stopped, or deleted with --delete-expired (requires --confirm)
+  - running RDEs not deployed for longer than their stop after deployment are stopped
+
+Run it on a schedule, from a cron job or a CI pipeline, to stop paying for forgotten RDEs.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if rdeReapDeleteExpired && !rdeConfirmFlag && !rdeReapDryRun {
+			utils.PrintlnError(fmt.Errorf("--delete-expired deletes the expired RDEs permanently"))
+			utils.Println("Run with --confirm to proceed: qovery rde reap --delete-expired --confirm")
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		var children []rdeChildInfo
+
+		if rdeBlueprintProjectName != "" {
+			bp, err := rdeFindBlueprintByProjectName(client, orgId, rdeBlueprintProjectName)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable")
+			}
+			children, err = rdeListChildren(client, orgId, bp.ProjectId)
+			checkError(err)
+		} else {
+			children, err = rdeListAllChildren(client, orgId)
+			checkError(err)
+		}
+
+		now := time.Now()
+		stopped := 0
+		deleted := 0
+
+		for _, child := range children {
+			name := strings.TrimPrefix(child.ProjectName, "rde-")
+
+			policy, err := rdeGetTTLPolicy(client, child.ProjectId)
+			if err != nil {
+				utils.Println(fmt.Sprintf("  WARNING: Skipping %s: %v", name, err))
+				continue
+			}
+			if policy.ExpiresAt == nil && policy.StopAfterDeploy == 0 {
+				continue
+			}
+
+			status, err := rdeGetEnvStatus(client, child.EnvId)
+			if err != nil {
+				utils.Println(fmt.Sprintf("  WARNING: Skipping %s: %v", name, err))
+				continue
+			}
+
+			action := rdeGetReapAction(policy, status, rdeGetLastDeployTime(client, child.EnvId), now, rdeReapDeleteExpired)
+			if action == rdeReapNone {
+				continue
+			}
+
+			reason := fmt.Sprintf("running for more than %s since its last deployment", policy.StopAfterDeploy)
+			if policy.ExpiresAt != nil && !now.Before(*policy.ExpiresAt) {
+				reason = rdeFormatRemaining(policy, now)
+			}
+
+			if rdeReapDryRun {
+				utils.Println(fmt.Sprintf("  Would %s %s (%s)", action, pterm.FgBlue.Sprintf("%s", name), reason))
+				continue
+			}
+
+			switch action {
+			case rdeReapStop:
+				utils.Println(fmt.Sprintf("  Stopping %s (%s)...", pterm.FgBlue.Sprintf("%s", name), reason))
+				_, _, err = client.EnvironmentActionsAPI.StopEnvironment(ctx(), child.EnvId).Execute()
+				if err != nil {
+					utils.Println(fmt.Sprintf("    WARNING: Stop failed for %s: %v", name, err))
+					continue
+				}
+				stopped++
+			case rdeReapDelete:
+				utils.Println(fmt.Sprintf("  %s %s", pterm.FgBlue.Sprintf("%s", name), reason))
+				if err := rdeDeleteByName(client, orgId, name); err != nil {
+					utils.Println(fmt.Sprintf("    WARNING: Delete failed for %s: %v", name, err))
+					continue
+				}
+				deleted++
+			}
+		}
+
+		if rdeReapDryRun {
+			return
+		}
+		utils.Println(fmt.Sprintf("\nReaped %d RDE(s): %d stopped, %d deleted", stopped+deleted, stopped, deleted))
+	},
+}
+
+func init() {
+	rdeCmd.AddCommand(rdeReapCmd)
+	rdeReapCmd.Flags().StringVarP(&rdeBlueprintProjectName, "blueprint", "b", "", "Only reap the RDEs of this Blueprint Project Name")
+	rdeReapCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeReapCmd.Flags().BoolVarP(&rdeReapDeleteExpired, "delete-expired", "", false, "Delete the expired RDEs instead of stopping them")
+	rdeReapCmd.Flags().BoolVarP(&rdeConfirmFlag, "confirm", "", false, "Confirm deletion of the expired RDEs")
+	rdeReapCmd.Flags().BoolVarP(&rdeReapDryRun, "dry-run", "", false, "Only show the RDEs to stop or delete")
+}
@@ -0,0 +1,683 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+)
+
+// rdeSnapshotVarPrefix prefixes the project variables holding the snapshots of an RDE
+const rdeSnapshotVarPrefix = "RDE_SNAPSHOT_"
+
+// RDE snapshot flag variables
+var rdeSnapshotName string
+var rdeSnapshotInPlace bool
+
+var rdeSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save and restore the state of an RDE",
+	Long: `Save and restore the state of an RDE.
+
+A snapshot captures the service versions (container tags, git branches and their deployed commits, chart versions),
+the resources (CPU, memory, instances) and the variables that differ from the blueprint.
+It is stored as a JSON project variable of the RDE (RDE_SNAPSHOT_<NAME>), so it survives
+a re-clone of the environment. The values of secrets are not readable and are not captured.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if len(args) == 0 {
+			_ = cmd.Help()
+			os.Exit(0)
+		}
+	},
+}
+
+func init() {
+	rdeCmd.AddCommand(rdeSnapshotCmd)
+}
+
+// rdeSnapshot is the state of an RDE, as stored in its project variables.
+type rdeSnapshot struct {
+	Name      string                `json:"name"`
+	CreatedAt time.Time             `json:"created_at"`
+	Services  []rdeServiceSnapshot  `json:"services"`
+	Variables []rdeVariableSnapshot `json:"variables"`
+}
+
+// rdeServiceSnapshot is the version and resources of a service of an RDE.
+// The version is the image tag of containers and image jobs, the chart version of helm repositories,
+// and the git branch otherwise. The commit id is the commit deployed from the git branch.
+type rdeServiceSnapshot struct {
+	Name                string `json:"name"`
+	Type                string `json:"type"`
+	Version             string `json:"version,omitempty"`
+	CommitId            string `json:"commit_id,omitempty"`
+	Cpu                 *int32 `json:"cpu,omitempty"`
+	Memory              *int32 `json:"memory,omitempty"`
+	MinRunningInstances *int32 `json:"min_running_instances,omitempty"`
+	MaxRunningInstances *int32 `json:"max_running_instances,omitempty"`
+}
+
+// rdeVariableSnapshot is a variable of an RDE differing from the blueprint. Service is empty for environment variables.
+type rdeVariableSnapshot struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Service string `json:"service,omitempty"`
+}
+
+// rdeSnapshotVariableKey returns the project variable holding the snapshot name.
+func rdeSnapshotVariableKey(name string) string {
+	return rdeVariableKey(rdeSnapshotVarPrefix, name)
+}
+
+// rdeVariableKey returns the variable key made of prefix and name, with the characters not allowed in keys replaced by '_'.
+func rdeVariableKey(prefix string, name string) string {
+	var key strings.Builder
+	key.WriteString(prefix)
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			key.WriteRune(r)
+		} else {
+			key.WriteRune('_')
+		}
+	}
+	return key.String()
+}
+
+// rdeDiffVariables returns the variables of the scope defined on the RDE whose value is missing or different in the blueprint,
+// and the keys of the secrets that could not be captured.
+// Aliases, overrides, external secrets and the variables managed by the RDE commands are ignored.
+func rdeDiffVariables(child []utils.EnvVarLineOutput, blueprint []utils.EnvVarLineOutput, scope string) ([]rdeVariableSnapshot, []string) {
+	bpByKey := make(map[string]utils.EnvVarLineOutput)
+	for _, v := range blueprint {
+		if isVariableOfScope(v, scope) {
+			bpByKey[v.Key] = v
+		}
+	}
+
+	var variables []rdeVariableSnapshot
+	var skipped []string
+	for _, v := range child {
+		if !isVariableOfScope(v, scope) || v.AliasParentKey != nil || v.OverrideParentKey != nil || v.SecretManagerAccessId != nil {
+			continue
+		}
+		if v.Key == rdeBlueprintKeyVar || v.Key == rdeOwnerEmailVar || v.Key == rdeBlueprintVersionVar {
+			continue
+		}
+
+		bp, inBlueprint := bpByKey[v.Key]
+		if v.IsSecret {
+			// the value of a secret is unknown, only the secrets added to the RDE are reported
+			if !inBlueprint {
+				skipped = append(skipped, v.Key)
+			}
+			continue
+		}
+		if v.Value == nil || (inBlueprint && !bp.IsSecret && bp.Value != nil && *bp.Value == *v.Value) {
+			continue
+		}
+		variables = append(variables, rdeVariableSnapshot{Key: v.Key, Value: *v.Value})
+	}
+
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Key < variables[j].Key
+	})
+	sort.Strings(skipped)
+	return variables, skipped
+}
+
+// rdeContainerSnapshot returns the snapshot of a container.
+func rdeContainerSnapshot(c qovery.ContainerResponse) rdeServiceSnapshot {
+	return rdeServiceSnapshot{
+		Name:                c.Name,
+		Type:                string(utils.ContainerType),
+		Version:             c.Tag,
+		Cpu:                 utils.Int32(c.Cpu),
+		Memory:              utils.Int32(c.Memory),
+		MinRunningInstances: utils.Int32(c.MinRunningInstances),
+		MaxRunningInstances: utils.Int32(c.MaxRunningInstances),
+	}
+}
+
+// rdeApplyContainerSnapshot sets the version and resources of the snapshot on the container.
+func rdeApplyContainerSnapshot(c *qovery.ContainerResponse, s rdeServiceSnapshot) {
+	if s.Version != "" {
+		c.Tag = s.Version
+	}
+	if s.Cpu != nil {
+		c.Cpu = *s.Cpu
+	}
+	if s.Memory != nil {
+		c.Memory = *s.Memory
+	}
+	if s.MinRunningInstances != nil {
+		c.MinRunningInstances = *s.MinRunningInstances
+	}
+	if s.MaxRunningInstances != nil {
+		c.MaxRunningInstances = *s.MaxRunningInstances
+	}
+}
+
+// rdeApplicationSnapshot returns the snapshot of an application.
+func rdeApplicationSnapshot(a qovery.Application) rdeServiceSnapshot {
+	snapshot := rdeServiceSnapshot{
+		Name:                a.Name,
+		Type:                string(utils.ApplicationType),
+		Cpu:                 a.Cpu,
+		Memory:              a.Memory,
+		MinRunningInstances: a.MinRunningInstances,
+		MaxRunningInstances: a.MaxRunningInstances,
+	}
+	if a.GitRepository != nil {
+		if a.GitRepository.Branch != nil {
+			snapshot.Version = *a.GitRepository.Branch
+		}
+		if a.GitRepository.DeployedCommitId != nil {
+			snapshot.CommitId = *a.GitRepository.DeployedCommitId
+		}
+	}
+	return snapshot
+}
+
+// rdeApplyApplicationSnapshot sets the version and resources of the snapshot on the application.
+func rdeApplyApplicationSnapshot(a *qovery.Application, s rdeServiceSnapshot) {
+	if s.Version != "" && a.GitRepository != nil {
+		branch := s.Version
+		a.GitRepository.Branch = &branch
+	}
+	if s.Cpu != nil {
+		a.Cpu = s.Cpu
+	}
+	if s.Memory != nil {
+		a.Memory = s.Memory
+	}
+	if s.MinRunningInstances != nil {
+		a.MinRunningInstances = s.MinRunningInstances
+	}
+	if s.MaxRunningInstances != nil {
+		a.MaxRunningInstances = s.MaxRunningInstances
+	}
+}
+
+// rdeJobSource returns the source of a cron or lifecycle job, or nil for other jobs.
+func rdeJobSource(job *qovery.JobResponse) *qovery.BaseJobResponseAllOfSource {
+	if job.CronJobResponse != nil {
+		return &job.CronJobResponse.Source
+	} else if job.LifecycleJobResponse != nil {
+		return &job.LifecycleJobResponse.Source
+	}
+	return nil
+}
+
+// rdeJobSnapshot returns the snapshot of a job.
+func rdeJobSnapshot(job qovery.JobResponse) rdeServiceSnapshot {
+	snapshot := rdeServiceSnapshot{Name: utils.GetJobName(&job), Type: string(utils.JobType)}
+	if detail := rdeExtractJobDetail(&job); detail != nil {
+		snapshot.Cpu = detail.cpu
+		snapshot.Memory = detail.memory
+	}
+
+	source := rdeJobSource(&job)
+	if source == nil {
+		return snapshot
+	}
+	if source.BaseJobResponseAllOfSourceOneOf != nil {
+		snapshot.Version = source.BaseJobResponseAllOfSourceOneOf.Image.Tag
+	} else if source.BaseJobResponseAllOfSourceOneOf1 != nil {
+		repo := source.BaseJobResponseAllOfSourceOneOf1.Docker.GitRepository
+		if repo != nil && repo.Branch != nil {
+			snapshot.Version = *repo.Branch
+		}
+		if repo != nil && repo.DeployedCommitId != nil {
+			snapshot.CommitId = *repo.DeployedCommitId
+		}
+	}
+	return snapshot
+}
+
+// rdeApplyJobSnapshot sets the version and resources of the snapshot on the job.
+func rdeApplyJobSnapshot(job *qovery.JobResponse, s rdeServiceSnapshot) {
+	if job.CronJobResponse != nil {
+		if s.Cpu != nil {
+			job.CronJobResponse.Cpu = *s.Cpu
+		}
+		if s.Memory != nil {
+			job.CronJobResponse.Memory = *s.Memory
+		}
+	} else if job.LifecycleJobResponse != nil {
+		if s.Cpu != nil {
+			job.LifecycleJobResponse.Cpu = *s.Cpu
+		}
+		if s.Memory != nil {
+			job.LifecycleJobResponse.Memory = *s.Memory
+		}
+	}
+
+	source := rdeJobSource(job)
+	if source == nil || s.Version == "" {
+		return
+	}
+	version := s.Version
+	if source.BaseJobResponseAllOfSourceOneOf != nil {
+		source.BaseJobResponseAllOfSourceOneOf.Image.Tag = version
+	} else if source.BaseJobResponseAllOfSourceOneOf1 != nil && source.BaseJobResponseAllOfSourceOneOf1.Docker.GitRepository != nil {
+		source.BaseJobResponseAllOfSourceOneOf1.Docker.GitRepository.Branch = &version
+	}
+}
+
+// rdeHelmSnapshot returns the snapshot of a helm.
+func rdeHelmSnapshot(h qovery.HelmResponse) rdeServiceSnapshot {
+	snapshot := rdeServiceSnapshot{Name: h.Name, Type: string(utils.HelmType)}
+	if h.Source.HelmResponseAllOfSourceOneOf != nil {
+		repo := h.Source.HelmResponseAllOfSourceOneOf.Git.GitRepository
+		if repo.Branch != nil {
+			snapshot.Version = *repo.Branch
+		}
+		if repo.DeployedCommitId != nil {
+			snapshot.CommitId = *repo.DeployedCommitId
+		}
+	} else if h.Source.HelmResponseAllOfSourceOneOf1 != nil {
+		snapshot.Version = h.Source.HelmResponseAllOfSourceOneOf1.Repository.ChartVersion
+	}
+	return snapshot
+}
+
+// rdeApplyHelmSnapshot sets the version of the snapshot on the helm.
+func rdeApplyHelmSnapshot(h *qovery.HelmResponse, s rdeServiceSnapshot) {
+	if s.Version == "" {
+		return
+	}
+	version := s.Version
+	if h.Source.HelmResponseAllOfSourceOneOf != nil {
+		h.Source.HelmResponseAllOfSourceOneOf.Git.GitRepository.Branch = &version
+	} else if h.Source.HelmResponseAllOfSourceOneOf1 != nil {
+		h.Source.HelmResponseAllOfSourceOneOf1.Repository.ChartVersion = version
+	}
+}
+
+// rdeListServices returns the services of an environment, with their snapshot.
+func rdeListServices(client *qovery.APIClient, envId string) ([]utils.Service, []rdeServiceSnapshot, error) {
+	var services []utils.Service
+	var snapshots []rdeServiceSnapshot
+	add := func(id string, snapshot rdeServiceSnapshot) {
+		services = append(services, utils.Service{ID: utils.Id(id), Name: utils.Name(snapshot.Name), Type: utils.ServiceType(snapshot.Type)})
+		snapshots = append(snapshots, snapshot)
+	}
+
+	containers, _, err := client.ContainersAPI.ListContainer(ctx(), envId).Execute()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, c := range containers.GetResults() {
+		add(c.Id, rdeContainerSnapshot(c))
+	}
+
+	applications, _, err := client.ApplicationsAPI.ListApplication(ctx(), envId).Execute()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, a := range applications.GetResults() {
+		add(a.Id, rdeApplicationSnapshot(a))
+	}
+
+	jobs, _, err := client.JobsAPI.ListJobs(ctx(), envId).Execute()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, j := range jobs.GetResults() {
+		add(utils.GetJobId(&j), rdeJobSnapshot(j))
+	}
+
+	helms, _, err := client.HelmsAPI.ListHelms(ctx(), envId).Execute()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, h := range helms.GetResults() {
+		add(h.Id, rdeHelmSnapshot(h))
+	}
+
+	return services, snapshots, nil
+}
+
+// rdeListVariableLines returns the variables of an environment, or of one of its services when service is set.
+func rdeListVariableLines(client *qovery.APIClient, envId string, service *utils.Service) ([]utils.EnvVarLineOutput, error) {
+	var variables []qovery.VariableResponse
+	var err error
+	if service == nil {
+		variables, err = utils.ListEnvironmentVariables(client, envId)
+	} else {
+		variables, err = utils.ListServiceVariables(client, string(service.ID), service.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []utils.EnvVarLineOutput
+	for _, v := range variables {
+		lines = append(lines, utils.FromEnvironmentVariableToEnvVarLineOutput(v))
+	}
+	return lines, nil
+}
+
+// rdeCaptureSnapshot captures the state of the RDE, with the keys of the secrets that could not be captured.
+func rdeCaptureSnapshot(client *qovery.APIClient, child *rdeChildInfo, name string) (*rdeSnapshot, []string, error) {
+	bpEnv, err := rdeFindBlueprintEnv(client, child.BlueprintProjectId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bpEnv == nil {
+		return nil, nil, fmt.Errorf("no blueprint environment found for %s", child.ProjectName)
+	}
+
+	services, serviceSnapshots, err := rdeListServices(client, child.EnvId)
+	if err != nil {
+		return nil, nil, err
+	}
+	bpServices, _, err := rdeListServices(client, bpEnv.EnvId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	snapshot := &rdeSnapshot{Name: name, CreatedAt: time.Now().UTC(), Services: serviceSnapshots}
+
+	childVars, err := rdeListVariableLines(client, child.EnvId, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	bpVars, err := rdeListVariableLines(client, bpEnv.EnvId, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	variables, skipped := rdeDiffVariables(childVars, bpVars, "environment")
+	snapshot.Variables = append(snapshot.Variables, variables...)
+
+	for i := range services {
+		service := services[i]
+		childVars, err := rdeListVariableLines(client, child.EnvId, &service)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var bpVars []utils.EnvVarLineOutput
+		for j := range bpServices {
+			if bpServices[j].Name == service.Name && bpServices[j].Type == service.Type {
+				bpVars, err = rdeListVariableLines(client, bpEnv.EnvId, &bpServices[j])
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+
+		variables, serviceSkipped := rdeDiffVariables(childVars, bpVars, "service")
+		for _, v := range variables {
+			v.Service = string(service.Name)
+			snapshot.Variables = append(snapshot.Variables, v)
+		}
+		for _, key := range serviceSkipped {
+			skipped = append(skipped, fmt.Sprintf("%s (%s)", key, service.Name))
+		}
+	}
+
+	return snapshot, skipped, nil
+}
+
+// rdeSaveSnapshot stores the snapshot in the project variables of the RDE, replacing the snapshot of the same name.
+func rdeSaveSnapshot(client *qovery.APIClient, projectId string, snapshot *rdeSnapshot) error {
+	content, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return rdeSetProjectVariable(client, projectId, rdeSnapshotVariableKey(snapshot.Name), string(content))
+}
+
+// rdeListSnapshots returns the snapshots stored in the project variables of the RDE, oldest first.
+func rdeListSnapshots(client *qovery.APIClient, projectId string) ([]rdeSnapshot, error) {
+	vars, err := utils.ListProjectVariables(client, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []rdeSnapshot
+	for _, v := range vars {
+		if !strings.HasPrefix(v.Key, rdeSnapshotVarPrefix) || !v.Value.IsSet() || v.Value.Get() == nil {
+			continue
+		}
+		var snapshot rdeSnapshot
+		if err := json.Unmarshal([]byte(*v.Value.Get()), &snapshot); err != nil {
+			return nil, fmt.Errorf("invalid snapshot %s: %w", v.Key, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt)
+	})
+	return snapshots, nil
+}
+
+// rdeGetSnapshot returns the snapshot name of the RDE.
+func rdeGetSnapshot(client *qovery.APIClient, projectId string, name string) (*rdeSnapshot, error) {
+	snapshots, err := rdeListSnapshots(client, projectId)
+	if err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		if rdeSnapshotVariableKey(snapshot.Name) == rdeSnapshotVariableKey(name) {
+			return &snapshot, nil
+		}
+	}
+	return nil, fmt.Errorf("snapshot %s not found", name)
+}
+
+// rdeRestoreSnapshot sets the versions, resources and variables of the snapshot on the services of the environment.
+// It returns the number of services restored.
+func rdeRestoreSnapshot(client *qovery.APIClient, projectId string, envId string, snapshot *rdeSnapshot) int {
+	restored := rdeRestoreServices(client, envId, snapshot.Services, utils.Println)
+	rdeRestoreVariables(client, projectId, envId, snapshot.Variables)
+	return restored
+}
+
+// rdeRestoreServices sets the versions and resources of the snapshots on the services of the environment,
+// using the same primitives as the blueprint sync. Each service is reported to log, and the number of services restored is returned.
+func rdeRestoreServices(client *qovery.APIClient, envId string, services []rdeServiceSnapshot, log func(string)) int {
+	byService := make(map[string]rdeServiceSnapshot)
+	for _, s := range services {
+		byService[s.Type+"/"+s.Name] = s
+	}
+	serviceSnapshot := func(serviceType utils.ServiceType, name string) (rdeServiceSnapshot, bool) {
+		s, ok := byService[string(serviceType)+"/"+name]
+		return s, ok
+	}
+	report := func(serviceType utils.ServiceType, name string, err error) {
+		if err != nil {
+			log(fmt.Sprintf("    WARNING: Failed to restore %s %s: %v", serviceType, name, err))
+		} else {
+			log(fmt.Sprintf("    Restored %s: %s", serviceType, name))
+		}
+	}
+
+	restored := 0
+	if containers, _, err := client.ContainersAPI.ListContainer(ctx(), envId).Execute(); err == nil {
+		for _, c := range containers.GetResults() {
+			if s, ok := serviceSnapshot(utils.ContainerType, c.Name); ok {
+				rdeApplyContainerSnapshot(&c, s)
+				err := rdeSyncContainer(client, c, c, true)
+				report(utils.ContainerType, c.Name, err)
+				if err == nil {
+					restored++
+				}
+			}
+		}
+	}
+	if applications, _, err := client.ApplicationsAPI.ListApplication(ctx(), envId).Execute(); err == nil {
+		for _, a := range applications.GetResults() {
+			if s, ok := serviceSnapshot(utils.ApplicationType, a.Name); ok {
+				rdeApplyApplicationSnapshot(&a, s)
+				err := rdeSyncApplication(client, a, a, true)
+				report(utils.ApplicationType, a.Name, err)
+				if err == nil {
+					restored++
+				}
+			}
+		}
+	}
+	if jobs, _, err := client.JobsAPI.ListJobs(ctx(), envId).Execute(); err == nil {
+		for _, j := range jobs.GetResults() {
+			name := utils.GetJobName(&j)
+			if s, ok := serviceSnapshot(utils.JobType, name); ok {
+				rdeApplyJobSnapshot(&j, s)
+				err := rdeSyncJob(client, j, j, true)
+				report(utils.JobType, name, err)
+				if err == nil {
+					restored++
+				}
+			}
+		}
+	}
+	if helms, _, err := client.HelmsAPI.ListHelms(ctx(), envId).Execute(); err == nil {
+		for _, h := range helms.GetResults() {
+			if s, ok := serviceSnapshot(utils.HelmType, h.Name); ok {
+				rdeApplyHelmSnapshot(&h, s)
+				err := rdeSyncHelm(client, h, h)
+				report(utils.HelmType, h.Name, err)
+				if err == nil {
+					restored++
+				}
+			}
+		}
+	}
+
+	return restored
+}
+
+// rdeDeployServices deploys the environment with the git sources of its services pinned to the commits of the snapshots,
+// so that a branch moving since the snapshot does not change the code deployed. Like an environment deployment,
+// every service is deployed, databases and terraform services included.
+func rdeDeployServices(client *qovery.APIClient, envId string, services []rdeServiceSnapshot) error {
+	commits := make(map[string]string)
+	for _, s := range services {
+		if s.CommitId != "" {
+			commits[s.Type+"/"+s.Name] = s.CommitId
+		}
+	}
+	if len(commits) == 0 {
+		_, _, err := client.EnvironmentActionsAPI.DeployEnvironment(ctx(), envId).Execute()
+		return err
+	}
+
+	envServices, _, err := rdeListServices(client, envId)
+	if err != nil {
+		return err
+	}
+	databases, _, err := client.DatabasesAPI.ListDatabase(ctx(), envId).Execute()
+	if err != nil {
+		return err
+	}
+	terraforms, _, err := client.TerraformsAPI.ListTerraforms(ctx(), envId).Execute()
+	if err != nil {
+		return err
+	}
+
+	request := qovery.DeployAllRequest{}
+	for _, service := range envServices {
+		id := string(service.ID)
+		var commitId *string
+		if commit, ok := commits[string(service.Type)+"/"+string(service.Name)]; ok {
+			commitId = &commit
+		}
+
+		switch service.Type {
+		case utils.ContainerType:
+			request.Containers = append(request.Containers, qovery.DeployAllRequestContainersInner{Id: id})
+		case utils.ApplicationType:
+			request.Applications = append(request.Applications, qovery.DeployAllRequestApplicationsInner{ApplicationId: id, GitCommitId: commitId})
+		case utils.JobType:
+			request.Jobs = append(request.Jobs, qovery.DeployAllRequestJobsInner{Id: &id, GitCommitId: commitId})
+		case utils.HelmType:
+			request.Helms = append(request.Helms, qovery.DeployAllRequestHelmsInner{Id: &id, GitCommitId: commitId})
+		}
+	}
+	for _, database := range databases.GetResults() {
+		request.Databases = append(request.Databases, database.Id)
+	}
+	for _, terraform := range terraforms.GetResults() {
+		request.Terraforms = append(request.Terraforms, qovery.TerraformDeployRequest{Id: *qovery.NewNullableString(&terraform.Id)})
+	}
+
+	_, _, err = client.EnvironmentActionsAPI.DeployAllServices(ctx(), envId).DeployAllRequest(request).Execute()
+	return err
+}
+
+// rdeRestoreVariables creates or updates the variables of the snapshot on the environment and its services.
+func rdeRestoreVariables(client *qovery.APIClient, projectId string, envId string, variables []rdeVariableSnapshot) {
+	byService := make(map[string]map[string]string)
+	for _, v := range variables {
+		if byService[v.Service] == nil {
+			byService[v.Service] = make(map[string]string)
+		}
+		byService[v.Service][v.Key] = v.Value
+	}
+	if len(byService) == 0 {
+		return
+	}
+
+	services, _, err := rdeListServices(client, envId)
+	if err != nil {
+		utils.Println(fmt.Sprintf("    WARNING: Failed to list services: %v", err))
+		return
+	}
+
+	targets := map[string]*variableSyncTarget{
+		"": {Scope: "environment", ProjectId: projectId, EnvironmentId: envId},
+	}
+	for i := range services {
+		targets[string(services[i].Name)] = &variableSyncTarget{Scope: "service", ProjectId: projectId, EnvironmentId: envId, Service: &services[i]}
+	}
+
+	for service, values := range byService {
+		label := "environment"
+		if service != "" {
+			label = fmt.Sprintf("service %s", service)
+		}
+
+		target, ok := targets[service]
+		if !ok {
+			utils.Println(fmt.Sprintf("    WARNING: Skipping the variables of %s, not found in the environment", label))
+			continue
+		}
+
+		err := rdeApplyVariables(client, target, values)
+		if err != nil {
+			utils.Println(fmt.Sprintf("    WARNING: Failed to restore the variables of %s: %v", label, err))
+		} else {
+			utils.Println(fmt.Sprintf("    Restored %d variable(s) of %s", len(values), label))
+		}
+	}
+}
+
+// rdeApplyVariables creates or updates the variables of the target to match values.
+func rdeApplyVariables(client *qovery.APIClient, target *variableSyncTarget, values map[string]string) error {
+	existing, err := target.listVariables(client)
+	if err != nil {
+		return err
+	}
+
+	plan, err := computeVariableSyncPlan(existing, target.Scope, values, nil, false)
+	if err != nil {
+		return err
+	}
+
+	for _, action := range plan {
+		if err := target.apply(client, action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
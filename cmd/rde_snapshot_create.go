@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var rdeSnapshotCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Save the service versions, resources and custom variables of an RDE",
+	Long: `Save the service versions, resources and custom variables of an RDE.
+
+Only the variables whose value differs from the blueprint are captured. Secrets cannot be
+read back from the API: the secrets added to the RDE are listed and must be set again after a restore.
+A snapshot with the same name is replaced.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		child, err := rdeFindChildByName(client, orgId, fmt.Sprintf("rde-%s", rdeName))
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		snapshot, skipped, err := rdeCaptureSnapshot(client, child, rdeSnapshotName)
+		checkError(err)
+
+		err = rdeSaveSnapshot(client, child.ProjectId, snapshot)
+		checkError(err)
+
+		utils.Println(fmt.Sprintf("Snapshot %s of %s saved (%d service(s), %d variable(s)).",
+			pterm.FgBlue.Sprintf("%s", snapshot.Name), pterm.FgBlue.Sprintf("%s", child.ProjectName), len(snapshot.Services), len(snapshot.Variables)))
+		if len(skipped) > 0 {
+			utils.Println(pterm.FgYellow.Sprintf("Secrets not captured (values are not readable): %s", strings.Join(skipped, ", ")))
+		}
+	},
+}
+
+func init() {
+	rdeSnapshotCmd.AddCommand(rdeSnapshotCreateCmd)
+	rdeSnapshotCreateCmd.Flags().StringVarP(&rdeName, "name", "n", "", "RDE Name")
+	rdeSnapshotCreateCmd.Flags().StringVarP(&rdeSnapshotName, "snapshot", "s", "", "Snapshot Name")
+	rdeSnapshotCreateCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+
+	_ = rdeSnapshotCreateCmd.MarkFlagRequired("name")
+	_ = rdeSnapshotCreateCmd.MarkFlagRequired("snapshot")
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var rdeSnapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the snapshots of an RDE",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		child, err := rdeFindChildByName(client, orgId, fmt.Sprintf("rde-%s", rdeName))
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		snapshots, err := rdeListSnapshots(client, child.ProjectId)
+		checkError(err)
+
+		if len(snapshots) == 0 {
+			utils.Println("No snapshots found.")
+			return
+		}
+
+		var data [][]string
+		for _, snapshot := range snapshots {
+			data = append(data, []string{
+				snapshot.Name,
+				snapshot.CreatedAt.Local().Format(time.RFC1123),
+				strconv.Itoa(len(snapshot.Services)),
+				strconv.Itoa(len(snapshot.Variables)),
+			})
+		}
+
+		err = utils.PrintTable([]string{"Name", "Created At", "Services", "Variables"}, data)
+		checkError(err)
+	},
+}
+
+func init() {
+	rdeSnapshotCmd.AddCommand(rdeSnapshotListCmd)
+	rdeSnapshotListCmd.Flags().StringVarP(&rdeName, "name", "n", "", "RDE Name")
+	rdeSnapshotListCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+
+	_ = rdeSnapshotListCmd.MarkFlagRequired("name")
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var rdeSnapshotRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a snapshot onto an RDE",
+	Long: `Restore a snapshot onto an RDE.
+
+By default, the environment of the RDE is deleted and re-cloned from the blueprint, then the
+service versions, resources and variables of the snapshot are applied and the environment is deployed,
+with the applications, jobs and helms built from git pinned to the commits deployed when the snapshot was taken.
+WARNING: uncommitted changes will be lost. Use --in-place to apply the snapshot on the current environment.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		child, err := rdeFindChildByName(client, orgId, fmt.Sprintf("rde-%s", rdeName))
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		snapshot, err := rdeGetSnapshot(client, child.ProjectId, rdeSnapshotName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		utils.Println(fmt.Sprintf("Restoring snapshot %s onto %s...", pterm.FgBlue.Sprintf("%s", snapshot.Name), pterm.FgBlue.Sprintf("%s", child.ProjectName)))
+
+		envId := child.EnvId
+		if !rdeSnapshotInPlace {
+			utils.Println("    WARNING: Uncommitted changes will be lost. Code in git is safe.")
+			newEnv := rdeRecloneEnvironment(client, child, utils.Println)
+			if newEnv == nil {
+				utils.PrintlnError(fmt.Errorf("could not re-clone %s from its blueprint", child.ProjectName))
+				os.Exit(1)
+				panic("unreachable")
+			}
+			envId = newEnv.Id
+		}
+
+		restored := rdeRestoreSnapshot(client, child.ProjectId, envId, snapshot)
+		utils.Println(fmt.Sprintf("Restored %d service(s).", restored))
+
+		if rdeSkipDeploy {
+			utils.Println("Skipping deployment (--skip-deploy).")
+			return
+		}
+
+		err = rdeDeployServices(client, envId, snapshot.Services)
+		checkError(err)
+		utils.Println(fmt.Sprintf("Deploying %s (env: %s)", pterm.FgBlue.Sprintf("%s", child.ProjectName), envId))
+	},
+}
+
+func init() {
+	rdeSnapshotCmd.AddCommand(rdeSnapshotRestoreCmd)
+	rdeSnapshotRestoreCmd.Flags().StringVarP(&rdeName, "name", "n", "", "RDE Name")
+	rdeSnapshotRestoreCmd.Flags().StringVarP(&rdeSnapshotName, "snapshot", "s", "", "Snapshot Name")
+	rdeSnapshotRestoreCmd.Flags().BoolVarP(&rdeSnapshotInPlace, "in-place", "", false, "Apply the snapshot on the current environment instead of a fresh clone from the blueprint")
+	rdeSnapshotRestoreCmd.Flags().BoolVarP(&rdeSkipDeploy, "skip-deploy", "", false, "Skip the deployment after the restore")
+	rdeSnapshotRestoreCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+
+	_ = rdeSnapshotRestoreCmd.MarkFlagRequired("name")
+	_ = rdeSnapshotRestoreCmd.MarkFlagRequired("snapshot")
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/qovery/qovery-client-go"
+)
+
+func TestRdeSnapshotVariableKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"before-refactor", "RDE_SNAPSHOT_BEFORE_REFACTOR"},
+		{"v2", "RDE_SNAPSHOT_V2"},
+		{"my snapshot.1", "RDE_SNAPSHOT_MY_SNAPSHOT_1"},
+	}
+
+	for _, tt := range tests {
+		if key := rdeSnapshotVariableKey(tt.name); key != tt.expected {
+			t.Errorf("rdeSnapshotVariableKey(%q) = %q, expected %q", tt.name, key, tt.expected)
+		}
+	}
+}
+
+func TestRdeDiffVariables(t *testing.T) {
+	environment := string(qovery.APIVARIABLESCOPEENUM_ENVIRONMENT)
+	variable := func(key string, value string) utils.EnvVarLineOutput {
+		return utils.EnvVarLineOutput{Key: key, Value: &value, Scope: environment}
+	}
+	secret := func(key string) utils.EnvVarLineOutput {
+		return utils.EnvVarLineOutput{Key: key, Scope: environment, IsSecret: true}
+	}
+	parentKey := "DATABASE_URL"
+	alias := variable("DB_ALIAS", "DATABASE_URL")
+	alias.AliasParentKey = &parentKey
+	project := variable("PROJECT_VAR", "project")
+	project.Scope = string(qovery.APIVARIABLESCOPEENUM_PROJECT)
+
+	blueprint := []utils.EnvVarLineOutput{
+		variable("LOG_LEVEL", "info"),
+		variable("FEATURE_FLAG", "off"),
+		secret("API_TOKEN"),
+	}
+	child := []utils.EnvVarLineOutput{
+		variable("LOG_LEVEL", "info"),
+		variable("FEATURE_FLAG", "on"),
+		variable("DEBUG", "true"),
+		variable(rdeOwnerEmailVar, "dev@example.com"),
+		secret("API_TOKEN"),
+		secret("MY_TOKEN"),
+		alias,
+		project,
+	}
+
+	variables, skipped := rdeDiffVariables(child, blueprint, "environment")
+
+	expected := []rdeVariableSnapshot{
+		{Key: "DEBUG", Value: "true"},
+		{Key: "FEATURE_FLAG", Value: "on"},
+	}
+	if !reflect.DeepEqual(variables, expected) {
+		t.Errorf("unexpected variables %v", variables)
+	}
+	if !reflect.DeepEqual(skipped, []string{"MY_TOKEN"}) {
+		t.Errorf("unexpected skipped secrets %v", skipped)
+	}
+}
@@ -28,6 +28,8 @@ var rdeStartCmd = &cobra.Command{
 			panic("unreachable")
 		}
 
+		rdeEnforceQuota(client, orgId, child.OwnerEmail, false, true, child.ProjectId)
+
 		_, _, err = client.EnvironmentActionsAPI.DeployEnvironment(ctx(), child.EnvId).Execute()
 		if err != nil {
 			utils.PrintlnError(fmt.Errorf("deploy failed: %w", err))
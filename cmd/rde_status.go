@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/pterm/pterm"
 	"github.com/qovery/qovery-cli/utils"
@@ -55,6 +56,16 @@ var rdeStatusCmd = &cobra.Command{
 		lastDeploy := rdeGetLastDeployTime(client, child.EnvId)
 		uptime := rdeFormatUptime(lastDeploy)
 		rows = append(rows, []string{"Uptime", uptime})
+
+		policy, err := rdeGetTTLPolicy(client, child.ProjectId)
+		if err == nil {
+			if policy.ExpiresAt != nil {
+				rows = append(rows, []string{"TTL", fmt.Sprintf("%s (%s)", rdeFormatRemaining(policy, time.Now()), policy.ExpiresAt.Local().Format(time.RFC1123))})
+			}
+			if policy.StopAfterDeploy > 0 {
+				rows = append(rows, []string{"Stop After Deploy", policy.StopAfterDeploy.String()})
+			}
+		}
 		rows = append(rows, []string{"Console", fmt.Sprintf("https://console.qovery.com/organization/%s/project/%s/environment/%s", orgId, child.ProjectId, child.EnvId)})
 
 		rdePrintKeyValueTable(rows)
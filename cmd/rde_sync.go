@@ -18,18 +18,19 @@ var rdeSyncStorage bool
 // rdeSyncServicesFromBlueprint reads all services from the blueprint environment and updates
 // the matching services in the child environment. Services are matched by name.
 // Source/image config is always synced. Additional config is synced based on flags.
-func rdeSyncServicesFromBlueprint(client *qovery.APIClient, blueprintEnvId string, childEnvId string) int {
+// Each synced service is reported to log.
+func rdeSyncServicesFromBlueprint(client *qovery.APIClient, blueprintEnvId string, childEnvId string, log func(string)) int {
 	synced := 0
-	synced += rdeSyncContainers(client, blueprintEnvId, childEnvId)
-	synced += rdeSyncApplications(client, blueprintEnvId, childEnvId)
-	synced += rdeSyncJobs(client, blueprintEnvId, childEnvId)
-	synced += rdeSyncHelms(client, blueprintEnvId, childEnvId)
+	synced += rdeSyncContainers(client, blueprintEnvId, childEnvId, log)
+	synced += rdeSyncApplications(client, blueprintEnvId, childEnvId, log)
+	synced += rdeSyncJobs(client, blueprintEnvId, childEnvId, log)
+	synced += rdeSyncHelms(client, blueprintEnvId, childEnvId, log)
 	return synced
 }
 
 // --- Container sync ---
 
-func rdeSyncContainers(client *qovery.APIClient, blueprintEnvId string, childEnvId string) int {
+func rdeSyncContainers(client *qovery.APIClient, blueprintEnvId string, childEnvId string, log func(string)) int {
 	bpContainers, _, err := client.ContainersAPI.ListContainer(ctx(), blueprintEnvId).Execute()
 	if err != nil || bpContainers == nil {
 		return 0
@@ -51,91 +52,97 @@ func rdeSyncContainers(client *qovery.APIClient, blueprintEnvId string, childEnv
 			continue
 		}
 
-		// Build storage from child or blueprint
-		var storage []qovery.ServiceStorageRequestStorageInner
-		srcStorage := child.Storage
-		if rdeSyncAll || rdeSyncStorage {
-			srcStorage = bp.Storage
-		}
-		for _, s := range srcStorage {
-			storage = append(storage, qovery.ServiceStorageRequestStorageInner{
-				Id:         &s.Id,
-				Type:       s.Type,
-				Size:       s.Size,
-				MountPoint: s.MountPoint,
-			})
+		err := rdeSyncContainer(client, child, bp, rdeSyncAll || rdeSyncResources)
+		if err != nil {
+			log(fmt.Sprintf("    WARNING: Failed to sync container %s: %v", child.Name, err))
+		} else {
+			log(fmt.Sprintf("    Synced container: %s (tag: %s)", pterm.FgBlue.Sprintf("%s", child.Name), bp.Tag))
+			synced++
 		}
+	}
 
-		// Build ports from child or blueprint
-		var ports []qovery.ServicePortRequestPortsInner
-		srcPorts := child.Ports
-		if rdeSyncAll || rdeSyncPorts {
-			srcPorts = bp.Ports
-		}
-		for _, p := range srcPorts {
-			ports = append(ports, qovery.ServicePortRequestPortsInner{
-				Name:               p.Name,
-				InternalPort:       p.InternalPort,
-				ExternalPort:       p.ExternalPort,
-				PubliclyAccessible: p.PubliclyAccessible,
-				IsDefault:          p.IsDefault,
-				Protocol:           &p.Protocol,
-			})
-		}
+	return synced
+}
 
-		cpu := utils.Int32(child.Cpu)
-		memory := utils.Int32(child.Memory)
-		minInst := utils.Int32(child.MinRunningInstances)
-		maxInst := utils.Int32(child.MaxRunningInstances)
-		autoscaling := utils.ConvertAutoscalingResponseToRequest(child.Autoscaling)
-		if rdeSyncAll || rdeSyncResources {
-			cpu = utils.Int32(bp.Cpu)
-			memory = utils.Int32(bp.Memory)
-			minInst = utils.Int32(bp.MinRunningInstances)
-			maxInst = utils.Int32(bp.MaxRunningInstances)
-			autoscaling = utils.ConvertAutoscalingResponseToRequest(bp.Autoscaling)
-		}
+// rdeSyncContainer updates the child container with the source of bp, and its resources when syncResources is set.
+func rdeSyncContainer(client *qovery.APIClient, child qovery.ContainerResponse, bp qovery.ContainerResponse, syncResources bool) error {
+	// Build storage from child or blueprint
+	var storage []qovery.ServiceStorageRequestStorageInner
+	srcStorage := child.Storage
+	if rdeSyncAll || rdeSyncStorage {
+		srcStorage = bp.Storage
+	}
+	for _, s := range srcStorage {
+		storage = append(storage, qovery.ServiceStorageRequestStorageInner{
+			Id:         &s.Id,
+			Type:       s.Type,
+			Size:       s.Size,
+			MountPoint: s.MountPoint,
+		})
+	}
 
-		healthchecks := child.Healthchecks
-		if rdeSyncAll || rdeSyncHealthchecks {
-			healthchecks = bp.Healthchecks
-		}
+	// Build ports from child or blueprint
+	var ports []qovery.ServicePortRequestPortsInner
+	srcPorts := child.Ports
+	if rdeSyncAll || rdeSyncPorts {
+		srcPorts = bp.Ports
+	}
+	for _, p := range srcPorts {
+		ports = append(ports, qovery.ServicePortRequestPortsInner{
+			Name:               p.Name,
+			InternalPort:       p.InternalPort,
+			ExternalPort:       p.ExternalPort,
+			PubliclyAccessible: p.PubliclyAccessible,
+			IsDefault:          p.IsDefault,
+			Protocol:           &p.Protocol,
+		})
+	}
 
-		req := qovery.ContainerRequest{
-			Storage:             storage,
-			Ports:               ports,
-			Name:                child.Name,
-			Description:         child.Description,
-			RegistryId:          bp.Registry.Id, // always sync source
-			ImageName:           bp.ImageName,   // always sync source
-			Tag:                 bp.Tag,         // always sync source
-			Arguments:           child.Arguments,
-			Entrypoint:          child.Entrypoint,
-			Cpu:                 cpu,
-			Memory:              memory,
-			MinRunningInstances: minInst,
-			MaxRunningInstances: maxInst,
-			Healthchecks:        healthchecks,
-			AutoPreview:         utils.Bool(child.AutoPreview),
-			AutoDeploy:          *qovery.NewNullableBool(child.AutoDeploy),
-			Autoscaling:         autoscaling,
-		}
+	cpu := utils.Int32(child.Cpu)
+	memory := utils.Int32(child.Memory)
+	minInst := utils.Int32(child.MinRunningInstances)
+	maxInst := utils.Int32(child.MaxRunningInstances)
+	autoscaling := utils.ConvertAutoscalingResponseToRequest(child.Autoscaling)
+	if syncResources {
+		cpu = utils.Int32(bp.Cpu)
+		memory = utils.Int32(bp.Memory)
+		minInst = utils.Int32(bp.MinRunningInstances)
+		maxInst = utils.Int32(bp.MaxRunningInstances)
+		autoscaling = utils.ConvertAutoscalingResponseToRequest(bp.Autoscaling)
+	}
 
-		_, _, err := client.ContainerMainCallsAPI.EditContainer(ctx(), child.Id).ContainerRequest(req).Execute()
-		if err != nil {
-			utils.Println(fmt.Sprintf("    WARNING: Failed to sync container %s: %v", child.Name, err))
-		} else {
-			utils.Println(fmt.Sprintf("    Synced container: %s (tag: %s)", pterm.FgBlue.Sprintf("%s", child.Name), bp.Tag))
-			synced++
-		}
+	healthchecks := child.Healthchecks
+	if rdeSyncAll || rdeSyncHealthchecks {
+		healthchecks = bp.Healthchecks
 	}
 
-	return synced
+	req := qovery.ContainerRequest{
+		Storage:             storage,
+		Ports:               ports,
+		Name:                child.Name,
+		Description:         child.Description,
+		RegistryId:          bp.Registry.Id, // always sync source
+		ImageName:           bp.ImageName,   // always sync source
+		Tag:                 bp.Tag,         // always sync source
+		Arguments:           child.Arguments,
+		Entrypoint:          child.Entrypoint,
+		Cpu:                 cpu,
+		Memory:              memory,
+		MinRunningInstances: minInst,
+		MaxRunningInstances: maxInst,
+		Healthchecks:        healthchecks,
+		AutoPreview:         utils.Bool(child.AutoPreview),
+		AutoDeploy:          *qovery.NewNullableBool(child.AutoDeploy),
+		Autoscaling:         autoscaling,
+	}
+
+	_, _, err := client.ContainerMainCallsAPI.EditContainer(ctx(), child.Id).ContainerRequest(req).Execute()
+	return err
 }
 
 // --- Application sync ---
 
-func rdeSyncApplications(client *qovery.APIClient, blueprintEnvId string, childEnvId string) int {
+func rdeSyncApplications(client *qovery.APIClient, blueprintEnvId string, childEnvId string, log func(string)) int {
 	bpApps, _, err := client.ApplicationsAPI.ListApplication(ctx(), blueprintEnvId).Execute()
 	if err != nil || bpApps == nil {
 		return 0
@@ -157,80 +164,15 @@ func rdeSyncApplications(client *qovery.APIClient, blueprintEnvId string, childE
 			continue
 		}
 
-		// Build git repository request from blueprint (always sync source)
-		var gitRepo *qovery.ApplicationGitRepositoryRequest
-		if bp.GitRepository != nil {
-			gitRepo = &qovery.ApplicationGitRepositoryRequest{
-				Url:      bp.GitRepository.Url,
-				Branch:   bp.GitRepository.Branch,
-				RootPath: bp.GitRepository.RootPath,
-				Provider: bp.GitRepository.Provider,
-			}
-		}
-
-		var storage []qovery.ServiceStorageRequestStorageInner
-		srcStorage := child.Storage
-		if rdeSyncAll || rdeSyncStorage {
-			srcStorage = bp.Storage
-		}
-		for _, s := range srcStorage {
-			storage = append(storage, qovery.ServiceStorageRequestStorageInner{
-				Id:         &s.Id,
-				Type:       s.Type,
-				Size:       s.Size,
-				MountPoint: s.MountPoint,
-			})
-		}
-
-		cpu := child.Cpu
-		memory := child.Memory
-		minInst := child.MinRunningInstances
-		maxInst := child.MaxRunningInstances
-		if rdeSyncAll || rdeSyncResources {
-			cpu = bp.Cpu
-			memory = bp.Memory
-			minInst = bp.MinRunningInstances
-			maxInst = bp.MaxRunningInstances
-		}
-
-		healthchecks := child.Healthchecks
-		if rdeSyncAll || rdeSyncHealthchecks {
-			healthchecks = bp.Healthchecks
-		}
-
-		ports := child.Ports
-		if rdeSyncAll || rdeSyncPorts {
-			ports = bp.Ports
-		}
-
-		req := qovery.ApplicationEditRequest{
-			Storage:             storage,
-			Name:                &child.Name,
-			Description:         child.Description,
-			GitRepository:       gitRepo,           // always sync source
-			BuildMode:           bp.BuildMode,      // always sync source
-			DockerfilePath:      bp.DockerfilePath, // always sync source
-			Cpu:                 cpu,
-			Memory:              memory,
-			MinRunningInstances: minInst,
-			MaxRunningInstances: maxInst,
-			Healthchecks:        healthchecks,
-			AutoPreview:         child.AutoPreview,
-			Ports:               ports,
-			Arguments:           child.Arguments,
-			Entrypoint:          child.Entrypoint,
-			AutoDeploy:          *qovery.NewNullableBool(child.AutoDeploy),
-		}
-
-		_, _, err := client.ApplicationMainCallsAPI.EditApplication(ctx(), child.Id).ApplicationEditRequest(req).Execute()
+		err := rdeSyncApplication(client, child, bp, rdeSyncAll || rdeSyncResources)
 		if err != nil {
-			utils.Println(fmt.Sprintf("    WARNING: Failed to sync application %s: %v", child.Name, err))
+			log(fmt.Sprintf("    WARNING: Failed to sync application %s: %v", child.Name, err))
 		} else {
 			branch := ""
 			if bp.GitRepository != nil && bp.GitRepository.Branch != nil {
 				branch = *bp.GitRepository.Branch
 			}
-			utils.Println(fmt.Sprintf("    Synced application: %s (branch: %s)", pterm.FgBlue.Sprintf("%s", child.Name), branch))
+			log(fmt.Sprintf("    Synced application: %s (branch: %s)", pterm.FgBlue.Sprintf("%s", child.Name), branch))
 			synced++
 		}
 	}
@@ -238,9 +180,80 @@ func rdeSyncApplications(client *qovery.APIClient, blueprintEnvId string, childE
 	return synced
 }
 
+// rdeSyncApplication updates the child application with the source of bp, and its resources when syncResources is set.
+func rdeSyncApplication(client *qovery.APIClient, child qovery.Application, bp qovery.Application, syncResources bool) error {
+	// Build git repository request from blueprint (always sync source)
+	var gitRepo *qovery.ApplicationGitRepositoryRequest
+	if bp.GitRepository != nil {
+		gitRepo = &qovery.ApplicationGitRepositoryRequest{
+			Url:      bp.GitRepository.Url,
+			Branch:   bp.GitRepository.Branch,
+			RootPath: bp.GitRepository.RootPath,
+			Provider: bp.GitRepository.Provider,
+		}
+	}
+
+	var storage []qovery.ServiceStorageRequestStorageInner
+	srcStorage := child.Storage
+	if rdeSyncAll || rdeSyncStorage {
+		srcStorage = bp.Storage
+	}
+	for _, s := range srcStorage {
+		storage = append(storage, qovery.ServiceStorageRequestStorageInner{
+			Id:         &s.Id,
+			Type:       s.Type,
+			Size:       s.Size,
+			MountPoint: s.MountPoint,
+		})
+	}
+
+	cpu := child.Cpu
+	memory := child.Memory
+	minInst := child.MinRunningInstances
+	maxInst := child.MaxRunningInstances
+	if syncResources {
+		cpu = bp.Cpu
+		memory = bp.Memory
+		minInst = bp.MinRunningInstances
+		maxInst = bp.MaxRunningInstances
+	}
+
+	healthchecks := child.Healthchecks
+	if rdeSyncAll || rdeSyncHealthchecks {
+		healthchecks = bp.Healthchecks
+	}
+
+	ports := child.Ports
+	if rdeSyncAll || rdeSyncPorts {
+		ports = bp.Ports
+	}
+
+	req := qovery.ApplicationEditRequest{
+		Storage:             storage,
+		Name:                &child.Name,
+		Description:         child.Description,
+		GitRepository:       gitRepo,           // always sync source
+		BuildMode:           bp.BuildMode,      // always sync source
+		DockerfilePath:      bp.DockerfilePath, // always sync source
+		Cpu:                 cpu,
+		Memory:              memory,
+		MinRunningInstances: minInst,
+		MaxRunningInstances: maxInst,
+		Healthchecks:        healthchecks,
+		AutoPreview:         child.AutoPreview,
+		Ports:               ports,
+		Arguments:           child.Arguments,
+		Entrypoint:          child.Entrypoint,
+		AutoDeploy:          *qovery.NewNullableBool(child.AutoDeploy),
+	}
+
+	_, _, err := client.ApplicationMainCallsAPI.EditApplication(ctx(), child.Id).ApplicationEditRequest(req).Execute()
+	return err
+}
+
 // --- Job sync ---
 
-func rdeSyncJobs(client *qovery.APIClient, blueprintEnvId string, childEnvId string) int {
+func rdeSyncJobs(client *qovery.APIClient, blueprintEnvId string, childEnvId string, log func(string)) int {
 	bpJobs, _, err := client.JobsAPI.ListJobs(ctx(), blueprintEnvId).Execute()
 	if err != nil || bpJobs == nil {
 		return 0
@@ -263,56 +276,67 @@ func rdeSyncJobs(client *qovery.APIClient, blueprintEnvId string, childEnvId str
 			continue
 		}
 
-		childId := utils.GetJobId(&childJob)
-		bpSource := rdeJobResponseToRequestSource(&bp)
-		childDetail := rdeExtractJobDetail(&childJob)
-		if childDetail == nil || bpSource == nil {
+		if rdeExtractJobDetail(&childJob) == nil || rdeJobResponseToRequestSource(&bp) == nil {
 			continue
 		}
 
-		cpu := childDetail.cpu
-		memory := childDetail.memory
-		if rdeSyncAll || rdeSyncResources {
-			bpDetail := rdeExtractJobDetail(&bp)
-			if bpDetail != nil {
-				cpu = bpDetail.cpu
-				memory = bpDetail.memory
-			}
+		err := rdeSyncJob(client, childJob, bp, rdeSyncAll || rdeSyncResources)
+		if err != nil {
+			log(fmt.Sprintf("    WARNING: Failed to sync job %s: %v", childName, err))
+		} else {
+			log(fmt.Sprintf("    Synced job: %s", pterm.FgBlue.Sprintf("%s", childName)))
+			synced++
 		}
+	}
 
-		healthchecks := childDetail.healthchecks
-		if rdeSyncAll || rdeSyncHealthchecks {
-			bpDetail := rdeExtractJobDetail(&bp)
-			if bpDetail != nil {
-				healthchecks = bpDetail.healthchecks
-			}
-		}
+	return synced
+}
+
+// rdeSyncJob updates the child job with the source of bp, and its resources when syncResources is set.
+func rdeSyncJob(client *qovery.APIClient, childJob qovery.JobResponse, bp qovery.JobResponse, syncResources bool) error {
+	childName := utils.GetJobName(&childJob)
+	childId := utils.GetJobId(&childJob)
+	bpSource := rdeJobResponseToRequestSource(&bp)
+	childDetail := rdeExtractJobDetail(&childJob)
+	if childDetail == nil || bpSource == nil {
+		return fmt.Errorf("unsupported job type")
+	}
 
-		req := qovery.JobRequest{
-			Name:               childName,
-			Description:        childDetail.description,
-			Cpu:                cpu,
-			Memory:             memory,
-			MaxNbRestart:       childDetail.maxNbRestart,
-			MaxDurationSeconds: childDetail.maxDurationSeconds,
-			AutoPreview:        childDetail.autoPreview,
-			Port:               childDetail.port,
-			Source:             bpSource, // always sync source
-			Healthchecks:       healthchecks,
-			Schedule:           childDetail.schedule,
-			AutoDeploy:         childDetail.autoDeploy,
+	cpu := childDetail.cpu
+	memory := childDetail.memory
+	if syncResources {
+		bpDetail := rdeExtractJobDetail(&bp)
+		if bpDetail != nil {
+			cpu = bpDetail.cpu
+			memory = bpDetail.memory
 		}
+	}
 
-		_, _, err := client.JobMainCallsAPI.EditJob(ctx(), childId).JobRequest(req).Execute()
-		if err != nil {
-			utils.Println(fmt.Sprintf("    WARNING: Failed to sync job %s: %v", childName, err))
-		} else {
-			utils.Println(fmt.Sprintf("    Synced job: %s", pterm.FgBlue.Sprintf("%s", childName)))
-			synced++
+	healthchecks := childDetail.healthchecks
+	if rdeSyncAll || rdeSyncHealthchecks {
+		bpDetail := rdeExtractJobDetail(&bp)
+		if bpDetail != nil {
+			healthchecks = bpDetail.healthchecks
 		}
 	}
 
-	return synced
+	req := qovery.JobRequest{
+		Name:               childName,
+		Description:        childDetail.description,
+		Cpu:                cpu,
+		Memory:             memory,
+		MaxNbRestart:       childDetail.maxNbRestart,
+		MaxDurationSeconds: childDetail.maxDurationSeconds,
+		AutoPreview:        childDetail.autoPreview,
+		Port:               childDetail.port,
+		Source:             bpSource, // always sync source
+		Healthchecks:       healthchecks,
+		Schedule:           childDetail.schedule,
+		AutoDeploy:         childDetail.autoDeploy,
+	}
+
+	_, _, err := client.JobMainCallsAPI.EditJob(ctx(), childId).JobRequest(req).Execute()
+	return err
 }
 
 // rdeJobResponseToRequestSource converts a JobResponse source to a JobRequestAllOfSource.
@@ -439,7 +463,7 @@ func rdeExtractJobDetail(job *qovery.JobResponse) *jobDetail {
 
 // --- Helm sync ---
 
-func rdeSyncHelms(client *qovery.APIClient, blueprintEnvId string, childEnvId string) int {
+func rdeSyncHelms(client *qovery.APIClient, blueprintEnvId string, childEnvId string, log func(string)) int {
 	bpHelms, _, err := client.HelmsAPI.ListHelms(ctx(), blueprintEnvId).Execute()
 	if err != nil || bpHelms == nil {
 		return 0
@@ -461,29 +485,15 @@ func rdeSyncHelms(client *qovery.APIClient, blueprintEnvId string, childEnvId st
 			continue
 		}
 
-		bpSource := rdeConvertHelmSource(&bp.Source)
-		if bpSource == nil {
+		if rdeConvertHelmSource(&bp.Source) == nil {
 			continue
 		}
 
-		childValues := rdeConvertHelmValuesOverride(&child.ValuesOverride)
-
-		req := qovery.HelmRequest{
-			Name:                      child.Name,
-			Description:               child.Description,
-			TimeoutSec:                child.TimeoutSec,
-			AutoDeploy:                child.AutoDeploy,
-			Source:                    *bpSource, // always sync source
-			Arguments:                 child.Arguments,
-			AllowClusterWideResources: &child.AllowClusterWideResources,
-			ValuesOverride:            *childValues,
-		}
-
-		_, _, err := client.HelmMainCallsAPI.EditHelm(ctx(), child.Id).HelmRequest(req).Execute()
+		err := rdeSyncHelm(client, child, bp)
 		if err != nil {
-			utils.Println(fmt.Sprintf("    WARNING: Failed to sync helm %s: %v", child.Name, err))
+			log(fmt.Sprintf("    WARNING: Failed to sync helm %s: %v", child.Name, err))
 		} else {
-			utils.Println(fmt.Sprintf("    Synced helm: %s", pterm.FgBlue.Sprintf("%s", child.Name)))
+			log(fmt.Sprintf("    Synced helm: %s", pterm.FgBlue.Sprintf("%s", child.Name)))
 			synced++
 		}
 	}
@@ -491,6 +501,30 @@ func rdeSyncHelms(client *qovery.APIClient, blueprintEnvId string, childEnvId st
 	return synced
 }
 
+// rdeSyncHelm updates the child helm with the source of bp.
+func rdeSyncHelm(client *qovery.APIClient, child qovery.HelmResponse, bp qovery.HelmResponse) error {
+	bpSource := rdeConvertHelmSource(&bp.Source)
+	if bpSource == nil {
+		return fmt.Errorf("unsupported helm source")
+	}
+
+	childValues := rdeConvertHelmValuesOverride(&child.ValuesOverride)
+
+	req := qovery.HelmRequest{
+		Name:                      child.Name,
+		Description:               child.Description,
+		TimeoutSec:                child.TimeoutSec,
+		AutoDeploy:                child.AutoDeploy,
+		Source:                    *bpSource, // always sync source
+		Arguments:                 child.Arguments,
+		AllowClusterWideResources: &child.AllowClusterWideResources,
+		ValuesOverride:            *childValues,
+	}
+
+	_, _, err := client.HelmMainCallsAPI.EditHelm(ctx(), child.Id).HelmRequest(req).Execute()
+	return err
+}
+
 // rdeConvertHelmSource converts a HelmResponseAllOfSource to a HelmRequestAllOfSource.
 func rdeConvertHelmSource(src *qovery.HelmResponseAllOfSource) *qovery.HelmRequestAllOfSource {
 	if src.HelmResponseAllOfSourceOneOf != nil {
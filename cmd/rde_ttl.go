@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+)
+
+// RDE TTL project variables
+const rdeExpiresAtVar = "RDE_EXPIRES_AT"
+const rdeStopAfterDeployVar = "RDE_STOP_AFTER_DEPLOY"
+
+// RDE TTL flag variables
+var rdeTTLHours int
+var rdeStopAfterDeploy string
+
+var rdeTTLCmd = &cobra.Command{
+	Use:   "ttl",
+	Short: "Manage the time-to-live and stop after deployment policy of an RDE",
+	Long: `Manage the time-to-live and stop after deployment policy of an RDE.
+
+The policy is stored as project variables of the RDE:
+  - RDE_EXPIRES_AT        = <RFC 3339 date> (the RDE expires at this date)
+  - RDE_STOP_AFTER_DEPLOY = <duration> (the RDE is stopped when still running this long after its last deployment)
+
+The API does not report activity in the workspace of an RDE: an RDE in use is stopped as well once the duration
+has elapsed since its last deployment, redeploy it or extend the duration to keep it running.
+
+Expired RDEs and RDEs past their stop after deployment are stopped or deleted by 'qovery rde reap'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if len(args) == 0 {
+			_ = cmd.Help()
+			os.Exit(0)
+		}
+	},
+}
+
+func init() {
+	rdeCmd.AddCommand(rdeTTLCmd)
+}
+
+// rdeTTLPolicy is the time-to-live and stop after deployment policy of an RDE.
+type rdeTTLPolicy struct {
+	ExpiresAt       *time.Time
+	StopAfterDeploy time.Duration
+}
+
+// rdeParseTTLPolicy reads the policy from the project variables of an RDE.
+func rdeParseTTLPolicy(expiresAt string, stopAfterDeploy string) (rdeTTLPolicy, error) {
+	var policy rdeTTLPolicy
+
+	if expiresAt != "" {
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s %s: %w", rdeExpiresAtVar, expiresAt, err)
+		}
+		policy.ExpiresAt = &t
+	}
+
+	if stopAfterDeploy != "" {
+		d, err := time.ParseDuration(stopAfterDeploy)
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s %s: %w", rdeStopAfterDeployVar, stopAfterDeploy, err)
+		}
+		policy.StopAfterDeploy = d
+	}
+
+	return policy, nil
+}
+
+// rdeGetTTLPolicy returns the policy of the RDE project.
+func rdeGetTTLPolicy(client *qovery.APIClient, projectId string) (rdeTTLPolicy, error) {
+	vars, err := utils.ListProjectVariables(client, projectId)
+	if err != nil {
+		return rdeTTLPolicy{}, err
+	}
+
+	value := func(key string) string {
+		v := utils.FindEnvironmentVariableByKey(key, vars)
+		if v == nil || !v.Value.IsSet() || v.Value.Get() == nil {
+			return ""
+		}
+		return *v.Value.Get()
+	}
+
+	return rdeParseTTLPolicy(value(rdeExpiresAtVar), value(rdeStopAfterDeployVar))
+}
+
+// rdeSetProjectVariable creates or updates a project variable of an RDE.
+func rdeSetProjectVariable(client *qovery.APIClient, projectId string, key string, value string) error {
+	vars, err := utils.ListProjectVariables(client, projectId)
+	if err != nil {
+		return err
+	}
+
+	if utils.FindEnvironmentVariableByKey(key, vars) != nil {
+		return utils.UpdateProjectVariable(client, projectId, key, value)
+	}
+	return utils.CreateProjectVariable(client, projectId, key, value, false)
+}
+
+// rdeSetTTLPolicy records the expiry in hours from now (when hours > 0) and the stop after deployment (when set) of an RDE.
+// A stop after deployment of 0 removes it.
+func rdeSetTTLPolicy(client *qovery.APIClient, projectId string, hours int, stopAfterDeploy string) error {
+	if hours > 0 {
+		expiresAt := time.Now().Add(time.Duration(hours) * time.Hour).UTC().Format(time.RFC3339)
+		if err := rdeSetProjectVariable(client, projectId, rdeExpiresAtVar, expiresAt); err != nil {
+			return err
+		}
+	}
+
+	if stopAfterDeploy == "" {
+		return nil
+	}
+
+	d, err := time.ParseDuration(stopAfterDeploy)
+	if err != nil {
+		return fmt.Errorf("invalid --stop-after-deploy %s: %w", stopAfterDeploy, err)
+	}
+	if d == 0 {
+		_ = utils.DeleteProjectVar(client, projectId, rdeStopAfterDeployVar)
+		return nil
+	}
+	return rdeSetProjectVariable(client, projectId, rdeStopAfterDeployVar, d.String())
+}
+
+// rdeFormatRemaining formats the time left before the RDE expires.
+func rdeFormatRemaining(policy rdeTTLPolicy, now time.Time) string {
+	if policy.ExpiresAt == nil {
+		return "-"
+	}
+
+	remaining := policy.ExpiresAt.Sub(now)
+	if remaining <= 0 {
+		return fmt.Sprintf("expired %s ago", rdeFormatDuration(-remaining))
+	}
+	return rdeFormatDuration(remaining)
+}
+
+// rdeFormatDuration formats a duration like rdeFormatUptime.
+func rdeFormatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	} else if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	} else if d < 24*time.Hour {
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+}
+
+// RDE reap actions
+const (
+	rdeReapNone   = ""
+	rdeReapStop   = "stop"
+	rdeReapDelete = "delete"
+)
+
+// rdeGetReapAction returns what to do with an RDE: expired RDEs are stopped, or deleted when deleteExpired is set,
+// running RDEs not deployed for longer than their stop after deployment are stopped.
+func rdeGetReapAction(policy rdeTTLPolicy, status qovery.StateEnum, lastDeploy *time.Time, now time.Time, deleteExpired bool) string {
+	running := status != "" && status != qovery.STATEENUM_STOPPED && status != qovery.STATEENUM_STOPPING && status != qovery.STATEENUM_DELETED
+
+	if policy.ExpiresAt != nil && !now.Before(*policy.ExpiresAt) {
+		if deleteExpired {
+			return rdeReapDelete
+		}
+		if running {
+			return rdeReapStop
+		}
+		return rdeReapNone
+	}
+
+	if running && policy.StopAfterDeploy > 0 && lastDeploy != nil && now.Sub(*lastDeploy) >= policy.StopAfterDeploy {
+		return rdeReapStop
+	}
+
+	return rdeReapNone
+}
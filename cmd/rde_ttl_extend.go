@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var rdeTTLExtendCmd = &cobra.Command{
+	Use:   "extend",
+	Short: "Extend the time-to-live of an RDE",
+	Long:  `Push the expiry of an RDE back by --hours. An RDE already expired, or without expiry, expires --hours from now.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if rdeTTLHours <= 0 {
+			utils.PrintlnError(fmt.Errorf("--hours must be greater than 0"))
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		child, err := rdeFindChildByName(client, orgId, fmt.Sprintf("rde-%s", rdeName))
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		policy, err := rdeGetTTLPolicy(client, child.ProjectId)
+		checkError(err)
+
+		expiresAt := rdeExtendExpiry(policy, time.Now(), time.Duration(rdeTTLHours)*time.Hour)
+		err = rdeSetProjectVariable(client, child.ProjectId, rdeExpiresAtVar, expiresAt.UTC().Format(time.RFC3339))
+		checkError(err)
+
+		utils.Println(fmt.Sprintf("RDE %s now expires on %s", pterm.FgBlue.Sprintf("%s", rdeName), expiresAt.Local().Format(time.RFC1123)))
+	},
+}
+
+// rdeExtendExpiry returns the expiry of the policy pushed back by extension, counted from now when it is expired or unset.
+func rdeExtendExpiry(policy rdeTTLPolicy, now time.Time, extension time.Duration) time.Time {
+	if policy.ExpiresAt == nil || policy.ExpiresAt.Before(now) {
+		return now.Add(extension)
+	}
+	return policy.ExpiresAt.Add(extension)
+}
+
+func init() {
+	rdeTTLCmd.AddCommand(rdeTTLExtendCmd)
+	rdeTTLExtendCmd.Flags().StringVarP(&rdeName, "name", "n", "", "RDE Name")
+	rdeTTLExtendCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeTTLExtendCmd.Flags().IntVarP(&rdeTTLHours, "hours", "", 0, "Hours to add to the time-to-live")
+
+	_ = rdeTTLExtendCmd.MarkFlagRequired("name")
+	_ = rdeTTLExtendCmd.MarkFlagRequired("hours")
+}
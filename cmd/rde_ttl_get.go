@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var rdeTTLGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the time-to-live and stop after deployment of an RDE",
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		child, err := rdeFindChildByName(client, orgId, fmt.Sprintf("rde-%s", rdeName))
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		policy, err := rdeGetTTLPolicy(client, child.ProjectId)
+		checkError(err)
+
+		expiresAt := "never"
+		if policy.ExpiresAt != nil {
+			expiresAt = policy.ExpiresAt.Local().Format(time.RFC1123)
+		}
+		stopAfterDeploy := "-"
+		if policy.StopAfterDeploy > 0 {
+			stopAfterDeploy = policy.StopAfterDeploy.String()
+		}
+
+		rdePrintKeyValueTable([][]string{
+			{"RDE", pterm.FgBlue.Sprintf("%s", child.ProjectName)},
+			{"Expires At", expiresAt},
+			{"Remaining", rdeFormatRemaining(policy, time.Now())},
+			{"Stop After Deploy", stopAfterDeploy},
+		})
+	},
+}
+
+func init() {
+	rdeTTLCmd.AddCommand(rdeTTLGetCmd)
+	rdeTTLGetCmd.Flags().StringVarP(&rdeName, "name", "n", "", "RDE Name")
+	rdeTTLGetCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+
+	_ = rdeTTLGetCmd.MarkFlagRequired("name")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var rdeTTLSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set the time-to-live and stop after deployment of an RDE",
+	Long: `Set the expiry of an RDE to --hours from now and/or its stop after deployment policy.
+
+The duration is measured from the last deployment of the RDE: the API does not report activity in the workspace.
+Use --stop-after-deploy 0 to remove the stop after deployment policy.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		if rdeTTLHours <= 0 && rdeStopAfterDeploy == "" {
+			utils.PrintlnError(fmt.Errorf("--hours or --stop-after-deploy is required"))
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		child, err := rdeFindChildByName(client, orgId, fmt.Sprintf("rde-%s", rdeName))
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		err = rdeSetTTLPolicy(client, child.ProjectId, rdeTTLHours, rdeStopAfterDeploy)
+		checkError(err)
+
+		utils.Println(fmt.Sprintf("TTL policy of RDE %s has been updated", pterm.FgBlue.Sprintf("%s", rdeName)))
+	},
+}
+
+func init() {
+	rdeTTLCmd.AddCommand(rdeTTLSetCmd)
+	rdeTTLSetCmd.Flags().StringVarP(&rdeName, "name", "n", "", "RDE Name")
+	rdeTTLSetCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeTTLSetCmd.Flags().IntVarP(&rdeTTLHours, "hours", "", 0, "Hours from now until the RDE expires")
+	rdeTTLSetCmd.Flags().StringVarP(&rdeStopAfterDeploy, "stop-after-deploy", "", "", "Stop the RDE when still running this long after its last deployment (e.g. 8h, 30m)")
+	rdeTTLSetCmd.Example = "qovery rde ttl set --name alice --hours 72\n" +
+		"qovery rde ttl set --name alice --stop-after-deploy 4h"
+
+	_ = rdeTTLSetCmd.MarkFlagRequired("name")
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qovery/qovery-client-go"
+)
+
+func TestRdeParseTTLPolicy(t *testing.T) {
+	policy, err := rdeParseTTLPolicy("2026-03-01T18:00:00Z", "2h30m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.ExpiresAt == nil || !policy.ExpiresAt.Equal(time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected expiry %v", policy.ExpiresAt)
+	}
+	if policy.StopAfterDeploy != 150*time.Minute {
+		t.Errorf("unexpected stop after deploy %v", policy.StopAfterDeploy)
+	}
+
+	if _, err := rdeParseTTLPolicy("tomorrow", ""); err == nil {
+		t.Error("expected an error for an invalid expiry")
+	}
+	if _, err := rdeParseTTLPolicy("", "2 hours"); err == nil {
+		t.Error("expected an error for an invalid stop after deploy")
+	}
+}
+
+func TestRdeFormatRemaining(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) rdeTTLPolicy {
+		expiresAt := now.Add(d)
+		return rdeTTLPolicy{ExpiresAt: &expiresAt}
+	}
+
+	tests := []struct {
+		policy   rdeTTLPolicy
+		expected string
+	}{
+		{policy: rdeTTLPolicy{}, expected: "-"},
+		{policy: at(3*time.Hour + 20*time.Minute), expected: "3h 20m"},
+		{policy: at(50 * time.Hour), expected: "2d 2h"},
+		{policy: at(-2 * time.Hour), expected: "expired 2h 0m ago"},
+	}
+
+	for _, tt := range tests {
+		if remaining := rdeFormatRemaining(tt.policy, now); remaining != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, remaining)
+		}
+	}
+}
+
+func TestRdeGetReapAction(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	deployedLongAgo := now.Add(-5 * time.Hour)
+	deployedRecently := now.Add(-10 * time.Minute)
+
+	tests := []struct {
+		name          string
+		policy        rdeTTLPolicy
+		status        qovery.StateEnum
+		lastDeploy    *time.Time
+		deleteExpired bool
+		expected      string
+	}{
+		{name: "no policy", policy: rdeTTLPolicy{}, status: qovery.STATEENUM_DEPLOYED, lastDeploy: &deployedLongAgo, expected: rdeReapNone},
+		{name: "expired running", policy: rdeTTLPolicy{ExpiresAt: &past}, status: qovery.STATEENUM_DEPLOYED, expected: rdeReapStop},
+		{name: "expired stopped", policy: rdeTTLPolicy{ExpiresAt: &past}, status: qovery.STATEENUM_STOPPED, expected: rdeReapNone},
+		{name: "expired stopped deleted", policy: rdeTTLPolicy{ExpiresAt: &past}, status: qovery.STATEENUM_STOPPED, deleteExpired: true, expected: rdeReapDelete},
+		{name: "not expired", policy: rdeTTLPolicy{ExpiresAt: &future}, status: qovery.STATEENUM_DEPLOYED, deleteExpired: true, expected: rdeReapNone},
+		{name: "deployed long ago", policy: rdeTTLPolicy{StopAfterDeploy: 4 * time.Hour}, status: qovery.STATEENUM_DEPLOYED, lastDeploy: &deployedLongAgo, expected: rdeReapStop},
+		{name: "recently deployed", policy: rdeTTLPolicy{StopAfterDeploy: 4 * time.Hour}, status: qovery.STATEENUM_DEPLOYED, lastDeploy: &deployedRecently, expected: rdeReapNone},
+		{name: "deployed long ago but stopped", policy: rdeTTLPolicy{StopAfterDeploy: 4 * time.Hour}, status: qovery.STATEENUM_STOPPED, lastDeploy: &deployedLongAgo, expected: rdeReapNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if action := rdeGetReapAction(tt.policy, tt.status, tt.lastDeploy, now, tt.deleteExpired); action != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, action)
+			}
+		})
+	}
+}
+
+func TestRdeExtendExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	future := now.Add(2 * time.Hour)
+	past := now.Add(-2 * time.Hour)
+
+	if expiresAt := rdeExtendExpiry(rdeTTLPolicy{ExpiresAt: &future}, now, 4*time.Hour); !expiresAt.Equal(now.Add(6 * time.Hour)) {
+		t.Errorf("expected the expiry to be pushed back, got %v", expiresAt)
+	}
+	if expiresAt := rdeExtendExpiry(rdeTTLPolicy{ExpiresAt: &past}, now, 4*time.Hour); !expiresAt.Equal(now.Add(4 * time.Hour)) {
+		t.Errorf("expected the expiry to count from now, got %v", expiresAt)
+	}
+	if expiresAt := rdeExtendExpiry(rdeTTLPolicy{}, now, 4*time.Hour); !expiresAt.Equal(now.Add(4 * time.Hour)) {
+		t.Errorf("expected the expiry to count from now, got %v", expiresAt)
+	}
+}
@@ -12,6 +12,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var rdeUpgradeDryRun bool
+var rdeUpgradeParallel int
+var rdeUpgradeCanary int
+
 var rdeUpgradeCmd = &cobra.Command{
 	Use:   "upgrade",
 	Short: "Upgrade RDE(s) from the updated blueprint",
@@ -22,8 +26,21 @@ var rdeUpgradeCmd = &cobra.Command{
                       WARNING: uncommitted changes will be lost with reclone
 
 If --name is provided, upgrades a single RDE. Otherwise, upgrades all RDEs.
-When upgrading multiple RDEs with reclone, environments are deleted in parallel
-for faster execution.`,
+
+By default, all the RDEs are upgraded at once and the command returns as soon as
+their deployments are queued. With --parallel or --canary, the RDEs are upgraded
+--parallel at a time (default 1) and each upgrade waits for the deployment to end
+(up to 30 minutes). When it ends in error, the service versions and resources the
+RDE had before the upgrade are restored and redeployed. With --canary N, the first
+N RDEs are upgraded and must reach DEPLOYED before the others are upgraded.
+
+RDEs pinned to a blueprint release (see 'qovery rde blueprint release') are
+skipped, unless --blueprint-version is provided: the RDEs are then upgraded and
+the service versions of that release are applied, or with --blueprint-version head,
+they are unpinned and track the blueprint head again.
+
+With --dry-run, nothing is changed: the differences with the blueprint that the
+upgrade would apply are shown instead (see 'qovery rde drift').`,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Capture(cmd)
 
@@ -35,102 +52,104 @@ for faster execution.`,
 			os.Exit(1)
 			panic("unreachable")
 		}
+		if rdeUpgradeParallel < 1 || rdeUpgradeCanary < 0 {
+			utils.PrintlnError(fmt.Errorf("--parallel must be at least 1 and --canary cannot be negative"))
+			os.Exit(1)
+			panic("unreachable")
+		}
 
 		client := utils.GetQoveryClientPanicInCaseOfError()
 		orgId, err := rdeGetOrgId(client)
 		checkError(err)
 
-		if rdeName != "" {
-			// Upgrade a single RDE
-			child, err := rdeFindChildByName(client, orgId, fmt.Sprintf("rde-%s", rdeName))
-			if err != nil {
-				utils.PrintlnError(err)
-				os.Exit(1)
-				panic("unreachable")
-			}
+		children, err := rdeSelectChildren(client, orgId, rdeName, rdeBlueprintProjectName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
 
-			if rdeUpgradeStrategy == "image" {
-				rdeUpgradeImage(client, child)
-			} else {
-				rdeUpgradeRecloneSingle(client, child)
-			}
-		} else {
-			// Upgrade all RDEs
-			var children []rdeChildInfo
+		if len(children) == 0 {
+			utils.Println("No RDE instances found.")
+			return
+		}
 
-			if rdeBlueprintProjectName != "" {
-				bp, err := rdeFindBlueprintByProjectName(client, orgId, rdeBlueprintProjectName)
+		var releases map[string]*rdeBlueprintRelease
+		if rdeBlueprintVersion == "" {
+			var pinned []rdeChildInfo
+			children, pinned = rdeSplitPinnedChildren(children)
+			for _, child := range pinned {
+				utils.PrintlnInfo(fmt.Sprintf("Skipping %s, pinned to blueprint version %s (use --blueprint-version to roll it forward)", child.ProjectName, child.BlueprintVersion))
+			}
+			if len(children) == 0 {
+				utils.Println("No RDE to upgrade.")
+				return
+			}
+		} else if rdeBlueprintVersion != rdeBlueprintHead {
+			releases = make(map[string]*rdeBlueprintRelease)
+			for _, child := range children {
+				if _, ok := releases[child.BlueprintProjectId]; ok {
+					continue
+				}
+				release, err := rdeGetBlueprintRelease(client, child.BlueprintProjectId, rdeBlueprintVersion)
 				if err != nil {
 					utils.PrintlnError(err)
 					os.Exit(1)
 					panic("unreachable")
 				}
-				children, err = rdeListChildren(client, orgId, bp.ProjectId)
-				checkError(err)
-			} else {
-				children, err = rdeListAllChildren(client, orgId)
-				checkError(err)
+				releases[child.BlueprintProjectId] = release
 			}
+		}
 
-			if len(children) == 0 {
-				utils.Println("No RDE instances found.")
-				return
+		if rdeUpgradeDryRun {
+			rdePrintDriftReport(client, children, rdeUpgradeDriftCategories())
+			if releases != nil {
+				utils.PrintlnInfo(fmt.Sprintf("The differences are with the blueprint head, the service versions of release %s are applied after the upgrade.", rdeBlueprintVersion))
 			}
+			return
+		}
 
+		wait := cmd.Flags().Changed("parallel") || cmd.Flags().Changed("canary")
+		if wait {
+			utils.Println(fmt.Sprintf("Upgrading %d RDE(s) (strategy: %s, parallel: %d)...", len(children), rdeUpgradeStrategy, rdeUpgradeParallel))
+		} else {
 			utils.Println(fmt.Sprintf("Upgrading %d RDE(s) (strategy: %s)...", len(children), rdeUpgradeStrategy))
+		}
+		if rdeUpgradeStrategy == "reclone" {
+			utils.Println("WARNING: Uncommitted changes will be lost. Code in git is safe.")
+		}
 
-			if rdeUpgradeStrategy == "image" {
-				for _, child := range children {
-					rdeUpgradeImage(client, &child)
-				}
-			} else {
-				rdeUpgradeRecloneAll(client, children)
-			}
+		if !rdeUpgradeRollout(client, children, rdeUpgradeStrategy, releases, wait, rdeUpgradeParallel, rdeUpgradeCanary) {
+			os.Exit(1)
+			panic("unreachable")
+		}
+		if wait {
 			utils.Println("\nAll RDEs upgraded.")
+		} else {
+			utils.Println("\nAll RDE deployments queued.")
 		}
 	},
 }
 
-// rdeUpgradeImage triggers a redeploy of an RDE environment.
-func rdeUpgradeImage(client *qovery.APIClient, child *rdeChildInfo) {
-	name := strings.TrimPrefix(child.ProjectName, "rde-")
-	utils.Println(fmt.Sprintf("  Upgrading %s (strategy: image - sync from blueprint and deploy)...", pterm.FgBlue.Sprintf("%s", name)))
-
-	// Resolve blueprint environment ID
-	bpEnvInfo, err := rdeFindBlueprintEnv(client, child.BlueprintProjectId)
-	if err != nil || bpEnvInfo == nil {
-		utils.Println(fmt.Sprintf("    ERROR: Could not find blueprint environment for %s", name))
-		return
-	}
-
-	// Sync service configurations from blueprint
-	synced := rdeSyncServicesFromBlueprint(client, bpEnvInfo.EnvId, child.EnvId)
-	if synced == 0 {
-		utils.Println(fmt.Sprintf("    No services matched between blueprint and %s, deploying as-is...", name))
-	} else {
-		utils.Println(fmt.Sprintf("    Synced %d service(s) from blueprint.", synced))
-	}
-
-	// Deploy
-	_, _, err = client.EnvironmentActionsAPI.DeployEnvironment(ctx(), child.EnvId).Execute()
-	if err != nil {
-		utils.Println(fmt.Sprintf("    WARNING: Deploy failed for %s: %v", name, err))
-	} else {
-		utils.Println(fmt.Sprintf("    Request to deploy %s has been queued..", pterm.FgBlue.Sprintf("%s", name)))
+// rdeUpgradeDriftCategories returns the categories of drift changed by the upgrade strategy.
+// A re-clone resets everything to the blueprint.
+func rdeUpgradeDriftCategories() map[string]bool {
+	if rdeUpgradeStrategy == "reclone" {
+		return nil
 	}
+	return rdeSyncDriftCategories(rdeSyncAll, rdeSyncResources, rdeSyncPorts, rdeSyncHealthchecks, rdeSyncStorage)
 }
 
-// rdeUpgradeRecloneSingle upgrades a single RDE by deleting its environment, waiting, and re-cloning from the blueprint.
-func rdeUpgradeRecloneSingle(client *qovery.APIClient, child *rdeChildInfo) {
+// rdeRecloneEnvironment deletes the environment of an RDE, waits, and re-clones it from the blueprint without deploying it.
+// Each step is reported to log.
+func rdeRecloneEnvironment(client *qovery.APIClient, child *rdeChildInfo, log func(string)) *qovery.Environment {
 	name := strings.TrimPrefix(child.ProjectName, "rde-")
-	utils.Println(fmt.Sprintf("  Upgrading %s (strategy: reclone - full re-clone from blueprint)...", pterm.FgBlue.Sprintf("%s", name)))
-	utils.Println("    WARNING: Uncommitted changes will be lost. Code in git is safe.")
 
 	// Resolve blueprint environment ID
 	bpEnvInfo, err := rdeFindBlueprintEnv(client, child.BlueprintProjectId)
 	if err != nil || bpEnvInfo == nil {
-		utils.Println(fmt.Sprintf("    ERROR: Could not find blueprint environment for %s", name))
-		return
+		log(fmt.Sprintf("    ERROR: Could not find blueprint environment for %s", name))
+		return nil
 	}
 
 	// Get blueprint cluster ID
@@ -140,17 +159,17 @@ func rdeUpgradeRecloneSingle(client *qovery.APIClient, child *rdeChildInfo) {
 	ownerEmail := child.OwnerEmail
 
 	// Delete the current environment
-	utils.Println(fmt.Sprintf("    Deleting environment %s...", pterm.FgBlue.Sprintf("%s", child.EnvName)))
+	log(fmt.Sprintf("    Deleting environment %s...", pterm.FgBlue.Sprintf("%s", child.EnvName)))
 	_, _ = client.EnvironmentMainCallsAPI.DeleteEnvironment(ctx(), child.EnvId).Execute()
 
 	// Wait for deletion
-	utils.Println("    Waiting for deletion to complete...")
+	log("    Waiting for deletion to complete...")
 	rdeWaitForEnvsDeletion(client, []string{child.EnvId}, 120*time.Second)
 
 	// Re-clone from blueprint environment
-	newEnv := rdeCloneFromBlueprint(client, child, bpEnvInfo.EnvId, bpClusterId)
+	newEnv := rdeCloneFromBlueprint(client, child, bpEnvInfo.EnvId, bpClusterId, log)
 	if newEnv == nil {
-		return
+		return nil
 	}
 
 	// Restore owner email
@@ -159,110 +178,13 @@ func rdeUpgradeRecloneSingle(client *qovery.APIClient, child *rdeChildInfo) {
 	}
 
 	// Update TTL job
-	rdeUpdateTTLJob(client, newEnv.Id)
-
-	// Deploy
-	_, _, err = client.EnvironmentActionsAPI.DeployEnvironment(ctx(), newEnv.Id).Execute()
-	if err != nil {
-		utils.Println(fmt.Sprintf("    WARNING: Deploy failed after re-clone for %s: %v", name, err))
-	} else {
-		utils.Println(fmt.Sprintf("    Re-cloned and deploying %s (env: %s)", pterm.FgBlue.Sprintf("%s", name), newEnv.Id))
-	}
-}
-
-// rdeUpgradeRecloneAll upgrades multiple RDEs in parallel phases:
-// Phase 1: Delete all environments (fire all delete requests)
-// Phase 2: Wait for all deletions to complete
-// Phase 3: Re-clone all from their blueprint
-// Phase 4: Deploy all
-func rdeUpgradeRecloneAll(client *qovery.APIClient, children []rdeChildInfo) {
-	utils.Println("  WARNING: Uncommitted changes will be lost. Code in git is safe.")
-	utils.Println("")
-
-	// Pre-resolve all blueprint env IDs and cluster IDs (grouped by blueprint project ID)
-	type blueprintRef struct {
-		envId     string
-		clusterId string
-	}
-	bpRefMap := make(map[string]*blueprintRef)
-	for _, child := range children {
-		if _, ok := bpRefMap[child.BlueprintProjectId]; !ok {
-			bpEnvInfo, err := rdeFindBlueprintEnv(client, child.BlueprintProjectId)
-			if err == nil && bpEnvInfo != nil {
-				clusterId := rdeGetBlueprintClusterId(client, bpEnvInfo.EnvId)
-				bpRefMap[child.BlueprintProjectId] = &blueprintRef{
-					envId:     bpEnvInfo.EnvId,
-					clusterId: clusterId,
-				}
-			}
-		}
-	}
-
-	// Phase 1: Delete all environments
-	utils.Println("  Phase 1/4: Deleting old environments...")
-	var envIdsToWait []string
-	for _, child := range children {
-		name := strings.TrimPrefix(child.ProjectName, "rde-")
-		_, _ = client.EnvironmentMainCallsAPI.DeleteEnvironment(ctx(), child.EnvId).Execute()
-		envIdsToWait = append(envIdsToWait, child.EnvId)
-		utils.Println(fmt.Sprintf("    Delete requested: %s", pterm.FgBlue.Sprintf("%s", name)))
-	}
-
-	// Phase 2: Wait for all deletions
-	utils.Println("")
-	utils.Println("  Phase 2/4: Waiting for deletions to complete...")
-	rdeWaitForEnvsDeletion(client, envIdsToWait, 180*time.Second)
-	utils.Println("    Deletions complete.")
-
-	// Phase 3: Re-clone all from blueprint
-	utils.Println("")
-	utils.Println("  Phase 3/4: Cloning from blueprint...")
-	type cloneResult struct {
-		child  rdeChildInfo
-		newEnv *qovery.Environment
-	}
-	var results []cloneResult
-	for _, child := range children {
-		name := strings.TrimPrefix(child.ProjectName, "rde-")
-		ref, ok := bpRefMap[child.BlueprintProjectId]
-		if !ok || ref == nil {
-			utils.Println(fmt.Sprintf("    ERROR: No blueprint environment found for %s, skipping", name))
-			continue
-		}
-
-		newEnv := rdeCloneFromBlueprint(client, &child, ref.envId, ref.clusterId)
-		if newEnv == nil {
-			continue
-		}
-
-		// Restore owner email
-		if child.OwnerEmail != "" {
-			_ = utils.CreateEnvironmentVariable(client, child.ProjectId, newEnv.Id, rdeOwnerEmailVar, child.OwnerEmail, false)
-		}
-
-		// Update TTL job
-		rdeUpdateTTLJob(client, newEnv.Id)
+	rdeUpdateTTLJob(client, newEnv.Id, log)
 
-		results = append(results, cloneResult{child: child, newEnv: newEnv})
-		utils.Println(fmt.Sprintf("    Cloned: %s (env: %s)", pterm.FgBlue.Sprintf("%s", name), newEnv.Id))
-	}
-
-	// Phase 4: Deploy all
-	utils.Println("")
-	utils.Println("  Phase 4/4: Deploying...")
-	for _, r := range results {
-		name := strings.TrimPrefix(r.child.ProjectName, "rde-")
-		_, _, err := client.EnvironmentActionsAPI.DeployEnvironment(ctx(), r.newEnv.Id).Execute()
-		if err != nil {
-			utils.Println(fmt.Sprintf("    WARNING: Deploy failed for %s: %v", name, err))
-		} else {
-			utils.Println(fmt.Sprintf("    Request to deploy %s has been queued..", pterm.FgBlue.Sprintf("%s", name)))
-		}
-	}
+	return newEnv
 }
 
-// rdeCloneFromBlueprint clones the blueprint environment into an RDE's project.
-func rdeCloneFromBlueprint(client *qovery.APIClient, child *rdeChildInfo, blueprintEnvId string, clusterId string) *qovery.Environment {
+// rdeCloneFromBlueprint clones the blueprint environment into an RDE's project, reporting errors to log.
+func rdeCloneFromBlueprint(client *qovery.APIClient, child *rdeChildInfo, blueprintEnvId string, clusterId string, log func(string)) *qovery.Environment {
 	name := strings.TrimPrefix(child.ProjectName, "rde-")
 
 	cloneReq := qovery.CloneEnvironmentRequest{
@@ -278,7 +200,7 @@ func rdeCloneFromBlueprint(client *qovery.APIClient, child *rdeChildInfo, bluepr
 	newEnv, _, err := client.EnvironmentActionsAPI.CloneEnvironment(ctx(), blueprintEnvId).
 		CloneEnvironmentRequest(cloneReq).Execute()
 	if err != nil {
-		utils.Println(fmt.Sprintf("    ERROR: Re-clone failed for %s: %v", name, err))
+		log(fmt.Sprintf("    ERROR: Re-clone failed for %s: %v", name, err))
 		return nil
 	}
 
@@ -291,6 +213,10 @@ func init() {
 	rdeUpgradeCmd.Flags().StringVarP(&rdeUpgradeStrategy, "strategy", "s", "image", "Upgrade strategy: 'image' (sync source and deploy) or 'reclone' (full re-clone)")
 	rdeUpgradeCmd.Flags().StringVarP(&rdeBlueprintProjectName, "blueprint", "b", "", "Filter by Blueprint Project Name (when upgrading all)")
 	rdeUpgradeCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeUpgradeCmd.Flags().StringVarP(&rdeBlueprintVersion, "blueprint-version", "", "", "Blueprint release to upgrade to, or 'head' to track the blueprint head (required for pinned RDEs)")
+	rdeUpgradeCmd.Flags().BoolVarP(&rdeUpgradeDryRun, "dry-run", "", false, "Only show the differences with the blueprint that would be applied")
+	rdeUpgradeCmd.Flags().IntVarP(&rdeUpgradeParallel, "parallel", "", 1, "Number of RDEs upgraded at the same time, each waiting for its deployment to end")
+	rdeUpgradeCmd.Flags().IntVarP(&rdeUpgradeCanary, "canary", "", 0, "Number of RDEs to upgrade first, the others are upgraded only if they reach DEPLOYED")
 
 	// Sync scope flags (used with --strategy image)
 	rdeUpgradeCmd.Flags().BoolVarP(&rdeSyncAll, "sync-all", "", false, "Sync all config from blueprint (resources, ports, healthchecks, storage)")
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/qovery/qovery-client-go"
+	"golang.org/x/term"
+)
+
+// Phases of the upgrade of an RDE
+const (
+	rdeUpgradePending     = "pending"
+	rdeUpgradeUpgrading   = "upgrading"
+	rdeUpgradeDeploying   = "deploying"
+	rdeUpgradeRollingBack = "rolling back"
+	rdeUpgradeQueued      = "queued"
+	rdeUpgradeDeployed    = "deployed"
+	rdeUpgradeRolledBack  = "rolled back"
+	rdeUpgradeFailed      = "failed"
+	rdeUpgradeSkipped     = "skipped"
+)
+
+// rdeUpgradeDeployTimeout is how long an upgrade waits for the deployment of an RDE to end
+const rdeUpgradeDeployTimeout = 30 * time.Minute
+
+// rdeUpgradeStatus is the progress of the upgrade of an RDE.
+type rdeUpgradeStatus struct {
+	Name      string
+	Phase     string
+	Detail    string
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// rdeUpgradeProgress tracks the upgrade of RDEs, shown as a table refreshed in place in a terminal,
+// and as one line per change otherwise.
+type rdeUpgradeProgress struct {
+	mu       sync.Mutex
+	statuses []rdeUpgradeStatus
+	area     *pterm.AreaPrinter
+}
+
+func newRdeUpgradeProgress(children []rdeChildInfo) *rdeUpgradeProgress {
+	progress := &rdeUpgradeProgress{}
+	for _, child := range children {
+		progress.statuses = append(progress.statuses, rdeUpgradeStatus{
+			Name:  strings.TrimPrefix(child.ProjectName, "rde-"),
+			Phase: rdeUpgradePending,
+		})
+	}
+
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		area, err := pterm.DefaultArea.Start()
+		if err == nil {
+			progress.area = area
+			progress.render()
+		}
+	}
+	return progress
+}
+
+// setPhase moves the RDE at index i to phase.
+func (p *rdeUpgradeProgress) setPhase(i int, phase string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status := &p.statuses[i]
+	if status.StartedAt.IsZero() && phase != rdeUpgradeSkipped {
+		status.StartedAt = time.Now()
+	}
+	if rdeIsUpgradeDone(phase) {
+		status.EndedAt = time.Now()
+	}
+	status.Phase = phase
+
+	if p.area == nil {
+		utils.Println(fmt.Sprintf("%s: %s", pterm.FgBlue.Sprintf("%s", status.Name), phase))
+	}
+	p.render()
+}
+
+// log returns the function reporting the steps of the upgrade of the RDE at index i.
+func (p *rdeUpgradeProgress) log(i int) func(string) {
+	return func(text string) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		status := &p.statuses[i]
+		status.Detail = strings.TrimSpace(text)
+
+		if p.area == nil {
+			utils.Println(fmt.Sprintf("%s: %s", pterm.FgBlue.Sprintf("%s", status.Name), status.Detail))
+		}
+		p.render()
+	}
+}
+
+func (p *rdeUpgradeProgress) render() {
+	if p.area == nil {
+		return
+	}
+
+	table := pterm.TableData{{"RDE", "Phase", "Elapsed", "Detail"}}
+	table = append(table, rdeUpgradeProgressRows(p.statuses, time.Now())...)
+	content, err := pterm.DefaultTable.WithHasHeader().WithData(table).Srender()
+	if err == nil {
+		p.area.Update(content)
+	}
+}
+
+func (p *rdeUpgradeProgress) stop() {
+	if p.area != nil {
+		_ = p.area.Stop()
+	}
+}
+
+// rdeIsUpgradeDone returns whether the upgrade of an RDE in phase is over.
+func rdeIsUpgradeDone(phase string) bool {
+	return phase == rdeUpgradeQueued || phase == rdeUpgradeDeployed || phase == rdeUpgradeRolledBack || phase == rdeUpgradeFailed || phase == rdeUpgradeSkipped
+}
+
+// rdeUpgradeProgressRows returns the rows of the progress table.
+func rdeUpgradeProgressRows(statuses []rdeUpgradeStatus, now time.Time) [][]string {
+	var rows [][]string
+	for _, status := range statuses {
+		elapsed := "-"
+		if !status.StartedAt.IsZero() {
+			end := now
+			if !status.EndedAt.IsZero() {
+				end = status.EndedAt
+			}
+			elapsed = end.Sub(status.StartedAt).Round(time.Second).String()
+		}
+		rows = append(rows, []string{status.Name, status.Phase, elapsed, status.Detail})
+	}
+	return rows
+}
+
+// rdeUpgradeRollout upgrades the RDEs, parallel at a time, to the release of their blueprint in releases,
+// or to the blueprint head when there is none. The first canary RDEs are upgraded first,
+// and the others are skipped unless all of them reach DEPLOYED.
+// Without wait, all the RDEs are upgraded at once and their deployments are only queued.
+// It returns whether all the RDEs were upgraded.
+func rdeUpgradeRollout(client *qovery.APIClient, children []rdeChildInfo, strategy string, releases map[string]*rdeBlueprintRelease, wait bool, parallel int, canary int) bool {
+	progress := newRdeUpgradeProgress(children)
+	if !wait {
+		parallel = len(children)
+	}
+
+	run := func(indexes []int) {
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, parallel)
+		for _, i := range indexes {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				progress.setPhase(i, rdeUpgradeOne(client, &children[i], strategy, releases[children[i].BlueprintProjectId], wait, progress, i))
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	var canaries, others []int
+	for i := range children {
+		if i < canary {
+			canaries = append(canaries, i)
+		} else {
+			others = append(others, i)
+		}
+	}
+
+	run(canaries)
+	canaryOk := true
+	for _, i := range canaries {
+		if progress.statuses[i].Phase != rdeUpgradeDeployed {
+			canaryOk = false
+		}
+	}
+	if canaryOk {
+		run(others)
+	} else {
+		for _, i := range others {
+			progress.log(i)("canary failed")
+			progress.setPhase(i, rdeUpgradeSkipped)
+		}
+	}
+	progress.stop()
+
+	counts := make(map[string]int)
+	for _, status := range progress.statuses {
+		counts[status.Phase]++
+	}
+	if wait {
+		utils.Println(fmt.Sprintf("%d deployed, %d rolled back, %d failed, %d skipped.",
+			counts[rdeUpgradeDeployed], counts[rdeUpgradeRolledBack], counts[rdeUpgradeFailed], counts[rdeUpgradeSkipped]))
+	} else {
+		utils.Println(fmt.Sprintf("%d queued, %d failed.", counts[rdeUpgradeQueued], counts[rdeUpgradeFailed]))
+	}
+	if !canaryOk {
+		utils.Println(pterm.FgYellow.Sprintf("The canary RDE(s) did not reach DEPLOYED, the other RDEs were not upgraded."))
+	}
+
+	return counts[rdeUpgradeDeployed]+counts[rdeUpgradeQueued] == len(children)
+}
+
+// rdeUpgradeOne upgrades an RDE, applies the release if not nil and deploys it. Without wait, it returns once the deployment is queued.
+// Otherwise it waits for the deployment, and when it ends in error, the service versions and resources the RDE had
+// before the upgrade are restored and redeployed. It returns the final phase.
+func rdeUpgradeOne(client *qovery.APIClient, child *rdeChildInfo, strategy string, release *rdeBlueprintRelease, wait bool, progress *rdeUpgradeProgress, i int) string {
+	progress.setPhase(i, rdeUpgradeUpgrading)
+	log := progress.log(i)
+
+	_, previous, err := rdeListServices(client, child.EnvId)
+	if err != nil {
+		log(fmt.Sprintf("WARNING: Could not read the current services, rollback disabled: %v", err))
+		previous = nil
+	}
+
+	envId := child.EnvId
+	if strategy == "reclone" {
+		newEnv := rdeRecloneEnvironment(client, child, log)
+		if newEnv == nil {
+			return rdeUpgradeFailed
+		}
+		envId = newEnv.Id
+	} else {
+		bpEnv, err := rdeFindBlueprintEnv(client, child.BlueprintProjectId)
+		if err != nil || bpEnv == nil {
+			log("ERROR: Could not find blueprint environment")
+			return rdeUpgradeFailed
+		}
+		synced := rdeSyncServicesFromBlueprint(client, bpEnv.EnvId, child.EnvId, log)
+		log(fmt.Sprintf("Synced %d service(s) from blueprint", synced))
+	}
+
+	if release != nil {
+		applied := rdeApplyBlueprintRelease(client, child.ProjectId, envId, release, log)
+		log(fmt.Sprintf("Applied blueprint version %s to %d service(s)", release.Version, applied))
+	} else if child.BlueprintVersion != "" {
+		if err := rdePinBlueprintVersion(client, child.ProjectId, envId, ""); err != nil {
+			log(fmt.Sprintf("WARNING: Failed to unpin blueprint version %s: %v", child.BlueprintVersion, err))
+		}
+	}
+
+	var pinned []rdeServiceSnapshot
+	if release != nil {
+		pinned = release.Services
+	}
+
+	if !wait {
+		if err := rdeDeployServices(client, envId, pinned); err != nil {
+			log(fmt.Sprintf("ERROR: Deploy failed: %v", err))
+			return rdeUpgradeFailed
+		}
+		log(fmt.Sprintf("Request to deploy has been queued (env: %s)", envId))
+		return rdeUpgradeQueued
+	}
+
+	progress.setPhase(i, rdeUpgradeDeploying)
+	state, err := rdeDeployAndWait(client, envId, pinned)
+	if err != nil {
+		log(fmt.Sprintf("ERROR: %v", err))
+		return rdeUpgradeFailed
+	}
+	if state == qovery.STATEENUM_DEPLOYED {
+		log(fmt.Sprintf("Deployed (env: %s)", envId))
+		return rdeUpgradeDeployed
+	}
+	if !strings.HasSuffix(string(state), "ERROR") || previous == nil {
+		log(fmt.Sprintf("Deployment ended in %s", state))
+		return rdeUpgradeFailed
+	}
+
+	progress.setPhase(i, rdeUpgradeRollingBack)
+	log(fmt.Sprintf("Deployment ended in %s, restoring the previous versions", state))
+	rdeRestoreServices(client, envId, previous, log)
+	if release != nil || child.BlueprintVersion != "" {
+		_ = rdePinBlueprintVersion(client, child.ProjectId, envId, child.BlueprintVersion)
+	}
+	state, err = rdeDeployAndWait(client, envId, previous)
+	if err != nil {
+		log(fmt.Sprintf("ERROR: Rollback failed: %v", err))
+		return rdeUpgradeFailed
+	}
+	if state != qovery.STATEENUM_DEPLOYED {
+		log(fmt.Sprintf("Rollback deployment ended in %s", state))
+		return rdeUpgradeFailed
+	}
+	log("Rolled back to the previous versions")
+	return rdeUpgradeRolledBack
+}
+
+// rdeDeployAndWait deploys the environment, with the git sources pinned to the commits of services,
+// and returns the state its deployment ends in.
+func rdeDeployAndWait(client *qovery.APIClient, envId string, services []rdeServiceSnapshot) (qovery.StateEnum, error) {
+	// the deployment history tells the new deployment apart from the previous one, whose state is returned until it is queued
+	previousId, err := rdeLastDeploymentId(client, envId)
+	if err != nil {
+		return "", err
+	}
+
+	err = rdeDeployServices(client, envId, services)
+	if err != nil {
+		return "", err
+	}
+
+	started := time.Now()
+	for time.Since(started) < rdeUpgradeDeployTimeout {
+		time.Sleep(5 * time.Second)
+
+		history, _, err := client.EnvironmentDeploymentHistoryAPI.ListEnvironmentDeploymentHistory(ctx(), envId).Execute()
+		if err != nil || len(history.GetResults()) == 0 {
+			continue
+		}
+
+		deployment := history.GetResults()[0]
+		if deployment.Id == previousId {
+			continue
+		}
+		if state := deployment.GetStatus(); utils.IsTerminalState(state) {
+			return state, nil
+		}
+	}
+
+	return "", fmt.Errorf("deployment did not end within %s", rdeUpgradeDeployTimeout)
+}
+
+// rdeLastDeploymentId returns the id of the last deployment of the environment, or an empty string when it was never deployed.
+func rdeLastDeploymentId(client *qovery.APIClient, envId string) (string, error) {
+	history, _, err := client.EnvironmentDeploymentHistoryAPI.ListEnvironmentDeploymentHistory(ctx(), envId).Execute()
+	if err != nil {
+		return "", err
+	}
+	if len(history.GetResults()) == 0 {
+		return "", nil
+	}
+	return history.GetResults()[0].Id, nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRdeUpgradeProgressRows(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	statuses := []rdeUpgradeStatus{
+		{Name: "alice", Phase: rdeUpgradeDeployed, Detail: "Deployed", StartedAt: now.Add(-5 * time.Minute), EndedAt: now.Add(-2 * time.Minute)},
+		{Name: "bob", Phase: rdeUpgradeDeploying, StartedAt: now.Add(-90 * time.Second)},
+		{Name: "carol", Phase: rdeUpgradePending},
+		{Name: "dave", Phase: rdeUpgradeSkipped, Detail: "canary failed", EndedAt: now},
+	}
+
+	expected := [][]string{
+		{"alice", "deployed", "3m0s", "Deployed"},
+		{"bob", "deploying", "1m30s", ""},
+		{"carol", "pending", "-", ""},
+		{"dave", "skipped", "-", "canary failed"},
+	}
+	if rows := rdeUpgradeProgressRows(statuses, now); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("unexpected rows %v", rows)
+	}
+}
+
+func TestRdeIsUpgradeDone(t *testing.T) {
+	tests := []struct {
+		phase    string
+		expected bool
+	}{
+		{rdeUpgradePending, false},
+		{rdeUpgradeUpgrading, false},
+		{rdeUpgradeDeploying, false},
+		{rdeUpgradeRollingBack, false},
+		{rdeUpgradeQueued, true},
+		{rdeUpgradeDeployed, true},
+		{rdeUpgradeRolledBack, true},
+		{rdeUpgradeFailed, true},
+		{rdeUpgradeSkipped, true},
+	}
+
+	for _, tt := range tests {
+		if done := rdeIsUpgradeDone(tt.phase); done != tt.expected {
+			t.Errorf("rdeIsUpgradeDone(%q) = %v, expected %v", tt.phase, done, tt.expected)
+		}
+	}
+}
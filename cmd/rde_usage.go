@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/qovery/qovery-cli/utils"
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+)
+
+// RDE usage flag variables
+var rdeUsageSince string
+
+var rdeUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show the RDE usage of each developer",
+	Long: `Show the RDE usage of each developer, identified by the owner email of the RDEs.
+
+For each developer:
+  RDEs           - number of RDEs, out of the quota if any
+  Running        - number of running RDEs, out of the quota if any
+  Running Hours  - time the RDEs were running over --since, from their deployment history
+  CPU / Memory   - resources currently requested by the services of the RDEs
+  vCPU / GiB Hrs - requested resources multiplied by the running time, to estimate the cost
+
+The deployment history is the one of the current environment of each RDE: it restarts when an RDE is recloned.
+If --blueprint is provided, only the RDEs of that blueprint are reported.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		since, err := time.ParseDuration(rdeUsageSince)
+		if err != nil || since <= 0 {
+			utils.PrintlnError(fmt.Errorf("invalid --since %s", rdeUsageSince))
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		orgId, err := rdeGetOrgId(client)
+		checkError(err)
+
+		children, err := rdeSelectChildren(client, orgId, "", rdeBlueprintProjectName)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable")
+		}
+
+		quota, err := rdeGetQuota(client, orgId)
+		checkError(err)
+
+		now := time.Now()
+		var usages []rdeUsage
+		for _, child := range children {
+			usages = append(usages, rdeGetUsage(client, &child, now.Add(-since), now))
+		}
+		owners := rdeAggregateUsage(usages)
+
+		if jsonFlag {
+			j, err := json.Marshal(owners)
+			checkError(err)
+			utils.Println(string(j))
+			return
+		}
+
+		if len(owners) == 0 {
+			utils.Println("No RDE instances found.")
+			return
+		}
+
+		var data [][]string
+		for _, owner := range owners {
+			data = append(data, []string{
+				owner.Owner,
+				rdeFormatQuotaUsage(owner.RDEs, quota.MaxPerOwner),
+				rdeFormatQuotaUsage(owner.Running, quota.MaxRunning),
+				fmt.Sprintf("%.1f", owner.RunningHours),
+				fmt.Sprintf("%d mCPU", owner.Cpu),
+				fmt.Sprintf("%d MiB", owner.Memory),
+				fmt.Sprintf("%.1f", owner.CpuHours),
+				fmt.Sprintf("%.1f", owner.MemoryGiBHours),
+			})
+		}
+
+		utils.Println(fmt.Sprintf("RDE usage over the last %s", pterm.FgBlue.Sprintf("%s", since)))
+		err = utils.PrintTable([]string{"Owner", "RDEs", "Running", "Running Hours", "CPU", "Memory", "vCPU Hours", "GiB Hours"}, data)
+		checkError(err)
+	},
+}
+
+func init() {
+	rdeCmd.AddCommand(rdeUsageCmd)
+	rdeUsageCmd.Flags().StringVarP(&organizationName, "organization", "o", "", "Organization Name")
+	rdeUsageCmd.Flags().StringVarP(&rdeBlueprintProjectName, "blueprint", "b", "", "Filter by Blueprint Project Name")
+	rdeUsageCmd.Flags().StringVarP(&rdeUsageSince, "since", "", "720h", "Period to compute the running hours over")
+	rdeUsageCmd.Flags().BoolVarP(&jsonFlag, "json", "", false, "JSON output")
+}
+
+// rdeUsage is the usage of an RDE.
+type rdeUsage struct {
+	Owner       string
+	Running     bool
+	RunningTime time.Duration
+	Cpu         int64
+	Memory      int64
+}
+
+// rdeOwnerUsage is the usage of the RDEs of a developer. Cpu is in millicores and Memory in MiB.
+type rdeOwnerUsage struct {
+	Owner          string  `json:"owner"`
+	RDEs           int     `json:"rdes"`
+	Running        int     `json:"running"`
+	RunningHours   float64 `json:"running_hours"`
+	Cpu            int64   `json:"cpu"`
+	Memory         int64   `json:"memory"`
+	CpuHours       float64 `json:"vcpu_hours"`
+	MemoryGiBHours float64 `json:"memory_gib_hours"`
+}
+
+// rdeDeploymentEvent is the end of a deployment action of an RDE.
+type rdeDeploymentEvent struct {
+	At    time.Time
+	State qovery.StateEnum
+}
+
+// rdeRunningDuration returns how long an RDE was running between from and now. A DEPLOYED or RESTARTED event
+// starts a running period, a STOPPED or DELETED event ends it. A period still open at the end of events lasts until now
+// when the RDE is running now.
+func rdeRunningDuration(events []rdeDeploymentEvent, runningNow bool, from time.Time, now time.Time) time.Duration {
+	sorted := make([]rdeDeploymentEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+
+	var total time.Duration
+	add := func(start time.Time, end time.Time) {
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(now) {
+			end = now
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+
+	running := false
+	var start time.Time
+	for _, event := range sorted {
+		switch event.State {
+		case qovery.STATEENUM_DEPLOYED, qovery.STATEENUM_RESTARTED:
+			if !running {
+				running = true
+				start = event.At
+			}
+		case qovery.STATEENUM_STOPPED, qovery.STATEENUM_DELETED:
+			if running {
+				add(start, event.At)
+				running = false
+			}
+		}
+	}
+	if running && runningNow {
+		add(start, now)
+	}
+
+	return total
+}
+
+// rdeRequestedResources returns the CPU in millicores and the memory in MiB requested by the services,
+// counting their minimum number of instances.
+func rdeRequestedResources(services []rdeServiceSnapshot) (int64, int64) {
+	var cpu, memory int64
+	for _, service := range services {
+		instances := int64(1)
+		if service.MinRunningInstances != nil && *service.MinRunningInstances > 1 {
+			instances = int64(*service.MinRunningInstances)
+		}
+		if service.Cpu != nil {
+			cpu += int64(*service.Cpu) * instances
+		}
+		if service.Memory != nil {
+			memory += int64(*service.Memory) * instances
+		}
+	}
+	return cpu, memory
+}
+
+// rdeAggregateUsage returns the usage of each owner, sorted by owner. RDEs without owner are reported as "-".
+func rdeAggregateUsage(usages []rdeUsage) []rdeOwnerUsage {
+	byOwner := make(map[string]*rdeOwnerUsage)
+	var owners []string
+	for _, usage := range usages {
+		name := usage.Owner
+		if name == "" {
+			name = "-"
+		}
+
+		owner, ok := byOwner[name]
+		if !ok {
+			owner = &rdeOwnerUsage{Owner: name}
+			byOwner[name] = owner
+			owners = append(owners, name)
+		}
+
+		hours := usage.RunningTime.Hours()
+		owner.RDEs++
+		if usage.Running {
+			owner.Running++
+		}
+		owner.RunningHours += hours
+		owner.Cpu += usage.Cpu
+		owner.Memory += usage.Memory
+		owner.CpuHours += float64(usage.Cpu) / 1000 * hours
+		owner.MemoryGiBHours += float64(usage.Memory) / 1024 * hours
+	}
+
+	sort.Strings(owners)
+	result := make([]rdeOwnerUsage, 0, len(owners))
+	for _, name := range owners {
+		result = append(result, *byOwner[name])
+	}
+	return result
+}
+
+// rdeFormatQuotaUsage returns the count out of the quota max for display.
+func rdeFormatQuotaUsage(count int, max int) string {
+	if max <= 0 {
+		return strconv.Itoa(count)
+	}
+	return fmt.Sprintf("%d/%d", count, max)
+}
+
+// rdeGetUsage returns the usage of an RDE between from and now.
+func rdeGetUsage(client *qovery.APIClient, child *rdeChildInfo, from time.Time, now time.Time) rdeUsage {
+	usage := rdeUsage{Owner: child.OwnerEmail}
+	if child.EnvId == "" {
+		return usage
+	}
+
+	if state, err := rdeGetEnvStatus(client, child.EnvId); err == nil {
+		usage.Running = rdeIsRunningState(state)
+	}
+
+	if _, services, err := rdeListServices(client, child.EnvId); err == nil {
+		usage.Cpu, usage.Memory = rdeRequestedResources(services)
+	}
+
+	history, _, err := client.EnvironmentDeploymentHistoryAPI.ListEnvironmentDeploymentHistory(ctx(), child.EnvId).Execute()
+	if err == nil {
+		var events []rdeDeploymentEvent
+		for _, deployment := range history.GetResults() {
+			at := deployment.GetUpdatedAt()
+			if at.IsZero() {
+				at = deployment.GetCreatedAt()
+			}
+			events = append(events, rdeDeploymentEvent{At: at, State: deployment.GetStatus()})
+		}
+		usage.RunningTime = rdeRunningDuration(events, usage.Running, from, now)
+	}
+
+	return usage
+}
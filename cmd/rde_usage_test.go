@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qovery/qovery-client-go"
+)
+
+func TestRdeRunningDuration(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	from := now.Add(-48 * time.Hour)
+	event := func(hoursAgo int, state qovery.StateEnum) rdeDeploymentEvent {
+		return rdeDeploymentEvent{At: now.Add(-time.Duration(hoursAgo) * time.Hour), State: state}
+	}
+
+	tests := []struct {
+		name       string
+		events     []rdeDeploymentEvent
+		runningNow bool
+		expected   time.Duration
+	}{
+		{name: "no history", expected: 0},
+		{
+			name:     "deployed then stopped",
+			events:   []rdeDeploymentEvent{event(10, qovery.STATEENUM_STOPPED), event(20, qovery.STATEENUM_DEPLOYED)},
+			expected: 10 * time.Hour,
+		},
+		{
+			name:       "still running",
+			events:     []rdeDeploymentEvent{event(5, qovery.STATEENUM_RESTARTED)},
+			runningNow: true,
+			expected:   5 * time.Hour,
+		},
+		{
+			name:     "open period of a stopped RDE",
+			events:   []rdeDeploymentEvent{event(5, qovery.STATEENUM_DEPLOYED)},
+			expected: 0,
+		},
+		{
+			name:     "clipped to the period",
+			events:   []rdeDeploymentEvent{event(60, qovery.STATEENUM_DEPLOYED), event(40, qovery.STATEENUM_STOPPED)},
+			expected: 8 * time.Hour,
+		},
+		{
+			name: "redeploy while running",
+			events: []rdeDeploymentEvent{
+				event(30, qovery.STATEENUM_DEPLOYED),
+				event(20, qovery.STATEENUM_DEPLOYED),
+				event(10, qovery.STATEENUM_DELETED),
+				event(5, qovery.STATEENUM_STOPPED),
+			},
+			expected: 20 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		if d := rdeRunningDuration(tt.events, tt.runningNow, from, now); d != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, d)
+		}
+	}
+}
+
+func TestRdeRequestedResources(t *testing.T) {
+	cpu, memory, instances := int32(500), int32(1024), int32(2)
+	services := []rdeServiceSnapshot{
+		{Name: "app", Cpu: &cpu, Memory: &memory, MinRunningInstances: &instances},
+		{Name: "db", Cpu: &cpu, Memory: &memory},
+		{Name: "chart"},
+	}
+
+	c, m := rdeRequestedResources(services)
+	if c != 1500 || m != 3072 {
+		t.Errorf("expected 1500 mCPU and 3072 MiB, got %d mCPU and %d MiB", c, m)
+	}
+}
+
+func TestRdeAggregateUsage(t *testing.T) {
+	owners := rdeAggregateUsage([]rdeUsage{
+		{Owner: "bob@example.com", Running: true, RunningTime: 10 * time.Hour, Cpu: 2000, Memory: 2048},
+		{Owner: "alice@example.com", RunningTime: 4 * time.Hour, Cpu: 500, Memory: 1024},
+		{Owner: "bob@example.com", Running: true, RunningTime: 2 * time.Hour, Cpu: 1000, Memory: 1024},
+		{Cpu: 100},
+	})
+
+	if len(owners) != 3 || owners[0].Owner != "-" || owners[1].Owner != "alice@example.com" || owners[2].Owner != "bob@example.com" {
+		t.Fatalf("unexpected owners %+v", owners)
+	}
+
+	bob := owners[2]
+	if bob.RDEs != 2 || bob.Running != 2 || bob.RunningHours != 12 || bob.Cpu != 3000 || bob.Memory != 3072 {
+		t.Errorf("unexpected usage %+v", bob)
+	}
+	if bob.CpuHours != 22 || bob.MemoryGiBHours != 22 {
+		t.Errorf("unexpected cost %+v", bob)
+	}
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/qovery/qovery-client-go"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var runWithPortForward bool
+
+// internalHostRewrite replaces an internal host of the environment by a port-forward on localhost.
+type internalHostRewrite struct {
+	Host string
+	// Ports maps the remote ports to the local ports
+	Ports map[uint16]uint16
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run -- <command> [args...]",
+	Short: "Run a local command with the environment variables of a service",
+	Long: `Run a local command with the environment variables of a service: aliases, overrides and interpolations are resolved like on Qovery.
+The API does not return the values of secrets and external secrets: they are read from the local environment when defined, otherwise they are prompted.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		client := utils.GetQoveryClientPanicInCaseOfError()
+		organizationId, projectId, environmentId, err := getOrganizationProjectEnvironmentContextResourcesIds(client)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		service, err := getServiceContextResourceId(client, serviceName, environmentId)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		if service == nil {
+			utils.PrintlnError(fmt.Errorf("service %s not found", serviceName))
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		variables, err := getServiceResolvedVariables(client, string(service.ID), service.Type)
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+
+		if runWithPortForward {
+			rewrites, err := startRunPortForwards(client, organizationId, projectId, environmentId)
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			for key, value := range variables {
+				variables[key] = rewriteInternalHosts(value, rewrites)
+			}
+		}
+
+		process := exec.Command(args[0], args[1:]...)
+		process.Stdin = os.Stdin
+		process.Stdout = os.Stdout
+		process.Stderr = os.Stderr
+		process.Env = os.Environ()
+		for key, value := range variables {
+			process.Env = append(process.Env, key+"="+value)
+		}
+
+		if err := process.Run(); err != nil {
+			var exitError *exec.ExitError
+			if errors.As(err, &exitError) {
+				os.Exit(exitError.ExitCode())
+			}
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	},
+}
+
+// getServiceResolvedVariables returns the variables of the service with their final value, secrets included.
+func getServiceResolvedVariables(client *qovery.APIClient, serviceId string, serviceType utils.ServiceType) (map[string]string, error) {
+	variablesResponse, err := utils.ListServiceVariables(client, serviceId, serviceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var variables []utils.EnvVarLineOutput
+	for _, variable := range variablesResponse {
+		variables = append(variables, utils.FromEnvironmentVariableToEnvVarLineOutput(variable))
+	}
+
+	return resolveRunVariables(variables, getSecretValue)
+}
+
+// resolveRunVariables returns the variables with their final value, the values of secrets being provided by secretValue.
+func resolveRunVariables(variables []utils.EnvVarLineOutput, secretValue func(key string) (string, error)) (map[string]string, error) {
+	// external secrets are returned with the path of the secret in the secret manager as value, which must not be injected
+	for i := range variables {
+		if variables[i].SecretManagerAccessId != nil {
+			variables[i].Value = nil
+		}
+	}
+
+	// the values of secrets are not returned by the API, they have to be provided before resolving aliases and interpolations
+	for key, value := range utils.ResolveVariables(variables) {
+		if value != nil || !isSecretToProvide(variables, key) {
+			continue
+		}
+
+		secret, err := secretValue(key)
+		if err != nil {
+			return nil, err
+		}
+		for i := range variables {
+			if variables[i].Key == key && variables[i].Value == nil {
+				variables[i].Value = &secret
+			}
+		}
+	}
+
+	resolved := make(map[string]string)
+	for key, value := range utils.ResolveVariables(variables) {
+		if value != nil {
+			resolved[key] = *value
+		}
+	}
+
+	return resolved, nil
+}
+
+// isSecretToProvide returns true if key is a secret or an external secret, and not an alias whose value comes from its parent.
+func isSecretToProvide(variables []utils.EnvVarLineOutput, key string) bool {
+	for _, variable := range variables {
+		if variable.Key == key && (variable.IsSecret || variable.SecretManagerAccessId != nil) && variable.AliasParentKey == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func getSecretValue(key string) (string, error) {
+	if value, ok := os.LookupEnv(key); ok {
+		return value, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("the value of the secret %s can't be fetched from Qovery, define it in your environment", key)
+	}
+
+	prompt := promptui.Prompt{
+		Label: fmt.Sprintf("Value of the secret %s", key),
+		Mask:  '*',
+	}
+	return prompt.Run()
+}
+
+// startRunPortForwards forwards the services of the environment on localhost, keeping their port when it is free.
+func startRunPortForwards(client *qovery.APIClient, organizationId string, projectId string, environmentId string) ([]internalHostRewrite, error) {
+	targets, err := getConnectTargets(client, organizationId, projectId, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	var rewrites []internalHostRewrite
+	var data [][]string
+	for _, target := range targets {
+		rewrite := internalHostRewrite{Host: target.Host, Ports: make(map[uint16]uint16)}
+		for _, port := range target.Ports {
+			localPort, err := getFreeLocalPort(port)
+			if err != nil {
+				return nil, err
+			}
+			rewrite.Ports[port] = localPort
+
+			req := target.Request
+			req.LocalPort = localPort
+			req.Port = port
+			go pkg.ExecPortForward(&req, nil)
+			data = append(data, []string{target.ServiceName, net.JoinHostPort(target.Host, strconv.Itoa(int(port))), net.JoinHostPort("localhost", strconv.Itoa(int(localPort)))})
+		}
+		rewrites = append(rewrites, rewrite)
+	}
+
+	_ = utils.PrintTable([]string{"Service", "Internal Address", "Local Address"}, data)
+
+	// let the listeners start before the command connects to them
+	time.Sleep(500 * time.Millisecond)
+
+	return rewrites, nil
+}
+
+// getFreeLocalPort returns port if it is free on localhost, another free port otherwise.
+func getFreeLocalPort(port uint16) (uint16, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(int(port))))
+	if err != nil {
+		listener, err = net.Listen("tcp", "localhost:0")
+		if err != nil {
+			return 0, err
+		}
+	}
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	return uint16(listener.Addr().(*net.TCPAddr).Port), nil
+}
+
+// rewriteInternalHosts replaces the internal hosts of value by localhost, and their ports by the forwarded ones.
+func rewriteInternalHosts(value string, rewrites []internalHostRewrite) string {
+	for _, rewrite := range rewrites {
+		if !strings.Contains(value, rewrite.Host) {
+			continue
+		}
+
+		remotePorts := make([]int, 0, len(rewrite.Ports))
+		for port := range rewrite.Ports {
+			remotePorts = append(remotePorts, int(port))
+		}
+		sort.Ints(remotePorts)
+
+		for _, port := range remotePorts {
+			localPort := rewrite.Ports[uint16(port)]
+			value = strings.ReplaceAll(value, fmt.Sprintf("%s:%d", rewrite.Host, port), fmt.Sprintf("localhost:%d", localPort))
+		}
+		value = strings.ReplaceAll(value, rewrite.Host, "localhost")
+	}
+
+	return value
+}
+
+func init() {
+	runCmd.Flags().StringVarP(&organizationName, "organization", "", "", "Organization Name")
+	runCmd.Flags().StringVarP(&projectName, "project", "", "", "Project Name")
+	runCmd.Flags().StringVarP(&environmentName, "environment", "", "", "Environment Name")
+	runCmd.Flags().StringVarP(&serviceName, "service", "", "", "Service Name")
+	runCmd.Flags().BoolVar(&runWithPortForward, "with-port-forward", false, "forward the services of the environment on localhost and rewrite their internal hosts in the variables")
+	runCmd.Example = "qovery run -- npm start\n" +
+		"qovery run --service <service_name> --with-port-forward -- go run ."
+
+	rootCmd.AddCommand(runCmd)
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/pterm/pterm"
+	"golang.org/x/term"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+// secretPattern is a well-known format of credentials
+type secretPattern struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+var secretPatterns = []secretPattern{
+	{Name: "AWS access key", Pattern: regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{Name: "GitHub token", Pattern: regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{Name: "GitLab token", Pattern: regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`)},
+	{Name: "Slack token", Pattern: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`)},
+	{Name: "Stripe secret key", Pattern: regexp.MustCompile(`\b[rs]k_live_[A-Za-z0-9]{16,}\b`)},
+	{Name: "private key", Pattern: regexp.MustCompile(`-----BEGIN ([A-Z0-9]+ )*PRIVATE KEY( BLOCK)?-----`)},
+	{Name: "JWT", Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+}
+
+// databaseUrlPattern matches the password of the URLs of the usual databases and brokers
+var databaseUrlPattern = regexp.MustCompile(`\b(postgres|postgresql|mysql|mariadb|mongodb|mongodb\+srv|redis|rediss|amqp|amqps|mssql|sqlserver)://[^:/@\s]+:([^@\s]+)@`)
+
+// secretKeyPattern matches the keys usually holding secrets
+var secretKeyPattern = regexp.MustCompile(`(?i)(SECRET|TOKEN|PASSWORD|PASSWD|PWD|API_?KEY|PRIVATE_?KEY|CREDENTIALS?)`)
+
+// highEntropyCharset are the characters of the random tokens and keys, base64 and hexadecimal included
+var highEntropyCharset = regexp.MustCompile(`^[A-Za-z0-9+/=_.-]+$`)
+
+const (
+	highEntropyMinLength = 20
+	highEntropyThreshold = 4.0
+)
+
+// detectSecret returns why the value of the variable key looks like a secret, or an empty string if it doesn't.
+// Values made of interpolated variables only are ignored, they don't hold the secret themselves.
+func detectSecret(key string, value string) string {
+	if strings.TrimSpace(interpolationPattern.ReplaceAllString(value, "")) == "" {
+		return ""
+	}
+
+	for _, pattern := range secretPatterns {
+		if pattern.Pattern.MatchString(value) {
+			return pattern.Name
+		}
+	}
+
+	if match := databaseUrlPattern.FindStringSubmatch(value); match != nil && !strings.Contains(match[2], "{{") {
+		return "database URL with a password"
+	}
+
+	if len(value) >= highEntropyMinLength && highEntropyCharset.MatchString(value) && shannonEntropy(value) >= highEntropyThreshold {
+		return "high entropy value"
+	}
+
+	if len(value) >= 8 && secretKeyPattern.MatchString(key) {
+		return "key usually holding a secret"
+	}
+
+	return ""
+}
+
+// shannonEntropy returns the entropy of value in bits per character
+func shannonEntropy(value string) float64 {
+	counts := make(map[rune]int)
+	for _, c := range value {
+		counts[c]++
+	}
+
+	length := float64(len([]rune(value)))
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// detectSecrets returns why the values of the variables look like secrets, by key.
+func detectSecrets(variables map[string]string) map[string]string {
+	reasons := make(map[string]string)
+	for key, value := range variables {
+		if reason := detectSecret(key, value); reason != "" {
+			reasons[key] = reason
+		}
+	}
+	return reasons
+}
+
+// proposeSecret warns when the value of a variable to create looks like a secret and, when running in a terminal,
+// proposes to create it as a secret instead. It returns whether the variable must be created as a secret.
+func proposeSecret(key string, value string, isSecret bool) bool {
+	if isSecret {
+		return true
+	}
+
+	reason := detectSecret(key, value)
+	if reason == "" {
+		return false
+	}
+
+	utils.Println(pterm.FgYellow.Sprintf("%s looks like a secret (%s)", key, reason))
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		utils.Println(pterm.FgYellow.Sprint("Use --secret to create it as a secret"))
+		return false
+	}
+
+	convert := true
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("Do you want to create %s as a secret instead?", key),
+		Default: true,
+	}
+	if err := survey.AskOne(prompt, &convert); err != nil {
+		return false
+	}
+	return convert
+}
+
+// proposeSecrets warns about the variables to import looking like secrets and proposes to import them as secrets.
+// It returns the keys to import as secrets.
+func proposeSecrets(variables map[string]string) map[string]bool {
+	reasons := detectSecrets(variables)
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	var options []string
+	for key := range reasons {
+		options = append(options, key)
+	}
+	sort.Strings(options)
+
+	for _, key := range options {
+		utils.Println(pterm.FgYellow.Sprintf("%s looks like a secret (%s)", key, reasons[key]))
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil
+	}
+
+	prompt := &survey.MultiSelect{
+		Message: "Which ones do you want to import as secrets?",
+		Options: options,
+		Default: options,
+	}
+
+	var selected []string
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return nil
+	}
+
+	secrets := make(map[string]bool)
+	for _, key := range selected {
+		secrets[key] = true
+	}
+	return secrets
+}
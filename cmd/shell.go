@@ -68,7 +68,23 @@ var shellCmd = &cobra.Command{
 		} else if cmd.Flags().Changed("mode") {
 			utils.PrintlnInfo("--mode has no effect without --ephemeral; ignoring it.")
 		}
-		pkg.ExecShell(shellRequest, endpoint)
+
+		var recorder *pkg.SessionRecorder
+		if shellRecordFile != "" {
+			recorder, err = pkg.NewSessionRecorder(shellRecordFile, fmt.Sprintf("qovery shell %s", shellRequest.ServiceID))
+			if err != nil {
+				utils.PrintlnError(err)
+				os.Exit(1)
+				panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+			}
+			defer func() {
+				if err := recorder.Close(); err != nil {
+					utils.PrintlnError(err)
+				}
+			}()
+		}
+
+		pkg.ExecShell(shellRequest, endpoint, recorder)
 	},
 }
 
@@ -80,6 +96,7 @@ var (
 	ephemeralMode    string
 	cpuOverride      string
 	memoryOverride   string
+	shellRecordFile  string
 )
 
 func shellRequestWithContextFlags() (*pkg.ShellRequest, error) {
@@ -372,12 +389,14 @@ func init() {
 	shellCmd.Flags().StringVar(&ephemeralMode, "mode", "clone", "ephemeral mode: 'clone' (new isolated pod, Heroku-style) or 'debug' (ephemeral container injected into existing pod, kubectl-debug style)")
 	shellCmd.Flags().StringVar(&cpuOverride, "cpu", "", "override CPU request+limit for the ephemeral pod (e.g. '500m', '2')")
 	shellCmd.Flags().StringVar(&memoryOverride, "memory", "", "override memory request+limit for the ephemeral pod (e.g. '512Mi', '2Gi')")
+	shellCmd.Flags().StringVar(&shellRecordFile, "record", "", "record the session to this file in the asciinema v2 format")
 	shellCmd.Example = "qovery shell\n" +
 		"qovery shell <qovery_console_service_url>\n" +
 		"qovery shell --organization <organization_name> --project <project_name> --environment <environment_name> --service <service_name>\n" +
 		"qovery shell --ephemeral --mode clone --organization <organization_name> --project <project_name> --environment <environment_name> --service <service_name>\n" +
 		"qovery shell --ephemeral --mode clone --memory 2Gi --organization <organization_name> --project <project_name> --environment <environment_name> --service <service_name>\n" +
-		"qovery shell --ephemeral --mode debug --organization <organization_name> --project <project_name> --environment <environment_name> --service <service_name>"
+		"qovery shell --ephemeral --mode debug --organization <organization_name> --project <project_name> --environment <environment_name> --service <service_name>\n" +
+		"qovery shell --record session.cast --organization <organization_name> --project <project_name> --environment <environment_name> --service <service_name>"
 
 	rootCmd.AddCommand(shellCmd)
 }
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/qovery/qovery-cli/pkg"
+	"github.com/qovery/qovery-cli/utils"
+)
+
+var (
+	replaySpeed   float64
+	replayMaxIdle time.Duration
+)
+
+var shellReplayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Play back a shell session recorded with --record",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Capture(cmd)
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+
+		if err := pkg.ReplaySession(file, os.Stdout, replaySpeed, replayMaxIdle); err != nil {
+			utils.PrintlnError(err)
+			os.Exit(1)
+			panic("unreachable") // staticcheck false positive: https://staticcheck.io/docs/checks#SA5011
+		}
+	},
+}
+
+func init() {
+	shellReplayCmd.Flags().Float64VarP(&replaySpeed, "speed", "s", 1, "playback speed multiplier, e.g. 2 plays the session twice as fast")
+	shellReplayCmd.Flags().DurationVar(&replayMaxIdle, "max-idle", 0, "shorten the pauses longer than this duration, e.g. '2s'")
+	shellReplayCmd.Example = "qovery shell replay session.cast\n" +
+		"qovery shell replay session.cast --speed 2 --max-idle 1s"
+
+	shellCmd.AddCommand(shellReplayCmd)
+}
@@ -0,0 +1,253 @@
+package pkg
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// TarRecordSize is the default record size of tar. Uploaded archives are padded to a multiple of it
+// so the remote tar reads the whole archive without waiting for an EOF the shell channel can't send.
+const TarRecordSize = 20 * 512
+
+// CopyTimeout is how long we wait for the remote tar to exit once the whole archive has been sent.
+const CopyTimeout = 30 * time.Second
+
+// CopyFromPod downloads remotePath from the pod targeted by req to localPath, like `kubectl cp pod:remote local`.
+// The remote file or directory is streamed as a tar archive through the shell exec channel.
+func CopyFromPod(req *ShellRequest, remotePath string, localPath string) error {
+	remotePath = path.Clean(remotePath)
+	req.Command = []string{"sh", "-c", fmt.Sprintf("tar cf - -C %s %s 2>/dev/null", shellQuote(path.Dir(remotePath)), shellQuote(path.Base(remotePath)))}
+	req.SetTtySize(0, 0)
+
+	wsConn, _, err := createWebsocketConn(req, "/shell/exec")
+	if err != nil {
+		return fmt.Errorf("websocket connection failed: %w", err)
+	}
+	defer func() {
+		_ = wsConn.Close()
+	}()
+
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(readBinaryMessages(wsConn, writer))
+	}()
+
+	count, err := untar(reader, path.Base(remotePath), localPath)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%s not found in the pod or not readable", remotePath)
+	}
+
+	return nil
+}
+
+// CopyToPod uploads localPath to remotePath in the pod targeted by req, like `kubectl cp local pod:remote`.
+// The remote tar is run through the exec wrapper, so that a failed extraction is reported with its exit code.
+func CopyToPod(req *ShellRequest, localPath string, remotePath string) error {
+	remotePath = path.Clean(remotePath)
+	req.Command = []string{"sh", "-c", execWrapper, "qovery-exec", "tar", "xf", "-", "-C", path.Dir(remotePath)}
+	req.SetTtySize(0, 0)
+
+	if _, err := os.Stat(localPath); err != nil {
+		return err
+	}
+
+	wsConn, _, err := createWebsocketConn(req, "/shell/exec")
+	if err != nil {
+		return fmt.Errorf("websocket connection failed: %w", err)
+	}
+	defer func() {
+		_ = wsConn.Close()
+	}()
+
+	// anything written by the remote tar is an error message
+	reader, pipeWriter := io.Pipe()
+	go func() {
+		_ = pipeWriter.CloseWithError(readBinaryMessages(wsConn, pipeWriter))
+	}()
+	done := make(chan error, 1)
+	go func() {
+		exitCode, err := demuxExecOutput(reader, os.Stderr, os.Stderr)
+		if err == nil && exitCode != 0 {
+			err = fmt.Errorf("remote tar exited with code %d", exitCode)
+		}
+		done <- err
+	}()
+
+	writer := &websocketWriter{conn: wsConn}
+	tarWriter := tar.NewWriter(writer)
+	if err := addToTar(tarWriter, localPath, path.Base(remotePath)); err != nil {
+		return err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if padding := writer.written % TarRecordSize; padding != 0 {
+		if _, err := writer.Write(make([]byte, TarRecordSize-padding)); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(CopyTimeout):
+		return errors.New("timeout while waiting for the remote tar to complete")
+	}
+}
+
+type websocketWriter struct {
+	conn    *websocket.Conn
+	written int
+}
+
+func (w *websocketWriter) Write(p []byte) (int, error) {
+	for offset := 0; offset < len(p); offset += StdinBufferSize {
+		end := min(offset+StdinBufferSize, len(p))
+		if err := w.conn.WriteMessage(websocket.BinaryMessage, p[offset:end]); err != nil {
+			return offset, err
+		}
+	}
+	w.written += len(p)
+	return len(p), nil
+}
+
+// readBinaryMessages copies the binary messages received on the websocket to out until the server closes the connection.
+func readBinaryMessages(wsConn *websocket.Conn, out io.Writer) error {
+	for {
+		msgType, msg, err := wsConn.ReadMessage()
+		if err != nil {
+			var e *websocket.CloseError
+			switch {
+			case errors.As(err, &e) && e.Code == websocket.CloseNormalClosure:
+				return nil
+			case IsPermanentCloseError(err):
+				return errors.New("copy rejected: check your permissions or run 'qovery auth'")
+			case IsInternalServerError(err):
+				return errors.New(ServiceUnavailableMessage("Copy"))
+			default:
+				return err
+			}
+		}
+
+		if msgType == websocket.CloseMessage {
+			return nil
+		}
+		if msgType != websocket.BinaryMessage {
+			continue
+		}
+
+		if _, err := out.Write(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// untar extracts an archive whose entries are rooted at name into destination, renaming name to destination.
+// It returns the number of extracted entries.
+func untar(in io.Reader, name string, destination string) (int, error) {
+	tarReader := tar.NewReader(in)
+	count := 0
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+
+		entryName := path.Clean(header.Name)
+		if entryName != name && !strings.HasPrefix(entryName, name+"/") {
+			return count, fmt.Errorf("unexpected entry %s in archive", header.Name)
+		}
+		target := filepath.Join(destination, filepath.FromSlash(strings.TrimPrefix(entryName, name)))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return count, err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return count, err
+			}
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode).Perm())
+			if err != nil {
+				return count, err
+			}
+			if _, err := io.Copy(file, tarReader); err != nil {
+				_ = file.Close()
+				return count, err
+			}
+			if err := file.Close(); err != nil {
+				return count, err
+			}
+		default:
+			// links and special files are skipped, like `kubectl cp` does
+			continue
+		}
+
+		count++
+	}
+}
+
+// addToTar adds the local file or directory source to the archive, under name.
+func addToTar(tarWriter *tar.Writer, source string, name string) error {
+	return filepath.Walk(source, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(source, file)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(name, filepath.ToSlash(relativePath))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		_, err = io.Copy(tarWriter, f)
+		return err
+	})
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
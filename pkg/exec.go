@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ExecTimeoutExitCode is returned when the command did not complete before the timeout, like timeout(1) does.
+const ExecTimeoutExitCode = 124
+
+// The shell channel merges stdout and stderr and does not report the exit status of the command.
+// The command is wrapped so that stderr lines are prefixed by execStderrMarker and the exit status
+// is written at the end of the output after execExitCodeMarker.
+const execStderrMarker = "\x1e"
+const execExitCodeMarker = "\x1f"
+const execWrapper = `status=$(mktemp); ` +
+	`{ { "$@"; echo $? > "$status"; } 2>&1 1>&3 3>&- | sed "s/^/$(printf '\036')/"; } 3>&1; ` +
+	`printf '\037%s\n' "$(cat "$status")"; rm -f "$status"`
+
+// ExecCommand runs req.Command once in the pod targeted by req, without tty and without reconnecting.
+// Remote stdout and stderr are written to stdout and stderr, and the exit code of the remote command is returned.
+func ExecCommand(ctx context.Context, req *ShellRequest, stdout io.Writer, stderr io.Writer) (int, error) {
+	execReq := *req
+	execReq.Command = append([]string{"sh", "-c", execWrapper, "qovery-exec"}, req.Command...)
+	execReq.SetTtySize(0, 0)
+
+	wsConn, resp, err := createWebsocketConn(&execReq, "/shell/exec")
+	if err != nil {
+		if resp != nil {
+			return 0, fmt.Errorf("websocket connection failed: %w (request id: %s)", err, resp.Header.Get("X-Qovery-Request-Id"))
+		}
+		return 0, fmt.Errorf("websocket connection failed: %w", err)
+	}
+	defer func() {
+		_ = wsConn.Close()
+	}()
+
+	// closing the connection unblocks the reader when the context is cancelled
+	stop := context.AfterFunc(ctx, func() {
+		_ = wsConn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		_ = wsConn.Close()
+	})
+	defer stop()
+
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(readBinaryMessages(wsConn, writer))
+	}()
+
+	exitCode, err := demuxExecOutput(reader, stdout, stderr)
+	if ctx.Err() != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ExecTimeoutExitCode, errors.New("timeout while waiting for the command to complete")
+		}
+		return 0, ctx.Err()
+	}
+
+	return exitCode, err
+}
+
+// demuxExecOutput splits the output of the wrapped command into stdout and stderr and returns the exit code.
+func demuxExecOutput(in io.Reader, stdout io.Writer, stderr io.Writer) (int, error) {
+	reader := bufio.NewReader(in)
+
+	for {
+		line, readErr := reader.ReadString('\n')
+
+		if before, after, found := strings.Cut(line, execExitCodeMarker); found {
+			if _, err := io.WriteString(stdout, before); err != nil {
+				return 0, err
+			}
+			exitCode, err := strconv.Atoi(strings.TrimSpace(after))
+			if err != nil {
+				return 0, fmt.Errorf("cannot read the exit code of the command: %q", after)
+			}
+			return exitCode, nil
+		}
+
+		// a stderr line can follow a stdout line which does not end with a new line
+		stdoutLine, stderrLine, isStderr := strings.Cut(line, execStderrMarker)
+		if _, err := io.WriteString(stdout, stdoutLine); err != nil {
+			return 0, err
+		}
+		if isStderr {
+			if _, err := io.WriteString(stderr, stderrLine); err != nil {
+				return 0, err
+			}
+		}
+
+		if readErr != nil {
+			if errors.Is(readErr, io.EOF) {
+				return 0, errors.New("connection closed before the command completed")
+			}
+			return 0, readErr
+		}
+	}
+}
+
+// PrefixWriter writes each line prefixed by Prefix. It is safe to share the same Mutex between writers
+// of the same output so that lines coming from several pods are not interleaved.
+type PrefixWriter struct {
+	Out    io.Writer
+	Prefix string
+	Mutex  *sync.Mutex
+	buffer []byte
+}
+
+func (w *PrefixWriter) Write(p []byte) (int, error) {
+	w.buffer = append(w.buffer, p...)
+
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	for {
+		index := bytes.IndexByte(w.buffer, '\n')
+		if index < 0 {
+			return len(p), nil
+		}
+		if _, err := io.WriteString(w.Out, w.Prefix+string(w.buffer[:index+1])); err != nil {
+			return 0, err
+		}
+		w.buffer = w.buffer[index+1:]
+	}
+}
+
+// Flush writes the last line if it does not end with a new line.
+func (w *PrefixWriter) Flush() error {
+	if len(w.buffer) == 0 {
+		return nil
+	}
+
+	w.Mutex.Lock()
+	defer w.Mutex.Unlock()
+	_, err := io.WriteString(w.Out, w.Prefix+string(w.buffer)+"\n")
+	w.buffer = nil
+	return err
+}
@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDemuxExecOutput(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       string
+		wantStdout   string
+		wantStderr   string
+		wantExitCode int
+		wantErr      bool
+	}{
+		{
+			name:         "stdout and stderr",
+			output:       "out\n\x1eerr\n\x1f3\n",
+			wantStdout:   "out\n",
+			wantStderr:   "err\n",
+			wantExitCode: 3,
+		},
+		{
+			name:         "stdout without trailing new line",
+			output:       "partial\x1eerr\nend\x1f0\n",
+			wantStdout:   "partialend",
+			wantStderr:   "err\n",
+			wantExitCode: 0,
+		},
+		{
+			name:       "connection closed before exit code",
+			output:     "out\n",
+			wantStdout: "out\n",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			exitCode, err := demuxExecOutput(strings.NewReader(tt.output), &stdout, &stderr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if exitCode != tt.wantExitCode || stdout.String() != tt.wantStdout || stderr.String() != tt.wantStderr {
+				t.Fatalf("got exit code %d, stdout %q, stderr %q", exitCode, stdout.String(), stderr.String())
+			}
+		})
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var out strings.Builder
+	writer := &PrefixWriter{Out: &out, Prefix: "[pod-1] ", Mutex: &sync.Mutex{}}
+
+	_, _ = writer.Write([]byte("first\nsec"))
+	_, _ = writer.Write([]byte("ond\nlast"))
+	_ = writer.Flush()
+
+	want := "[pod-1] first\n[pod-1] second\n[pod-1] last\n"
+	if out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+}
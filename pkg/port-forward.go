@@ -8,6 +8,10 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/appscode/go-querystring/query"
 	"github.com/gorilla/websocket"
@@ -25,6 +29,76 @@ type PortForwardRequest struct {
 	ServiceType    string   `url:"service_type"`
 	Port           uint16   `url:"port"`
 	LocalPort      uint16
+	ListenAddress  string `url:"-"`
+}
+
+// PortForwardDialAttempts is the number of times the websocket of a connection is dialed before giving up.
+const PortForwardDialAttempts = 3
+
+// PortForwardMetrics counts the connections and the traffic of a port-forward.
+// All methods are no-op on nil metrics.
+type PortForwardMetrics struct {
+	activeConnections atomic.Int64
+	totalConnections  atomic.Int64
+	failedConnections atomic.Int64
+	bytesSent         atomic.Int64
+	bytesReceived     atomic.Int64
+}
+
+// PortForwardStats is a snapshot of PortForwardMetrics.
+type PortForwardStats struct {
+	ActiveConnections int64 `json:"active_connections"`
+	TotalConnections  int64 `json:"total_connections"`
+	FailedConnections int64 `json:"failed_connections"`
+	BytesSent         int64 `json:"bytes_sent"`
+	BytesReceived     int64 `json:"bytes_received"`
+}
+
+func (m *PortForwardMetrics) Stats() PortForwardStats {
+	if m == nil {
+		return PortForwardStats{}
+	}
+
+	return PortForwardStats{
+		ActiveConnections: m.activeConnections.Load(),
+		TotalConnections:  m.totalConnections.Load(),
+		FailedConnections: m.failedConnections.Load(),
+		BytesSent:         m.bytesSent.Load(),
+		BytesReceived:     m.bytesReceived.Load(),
+	}
+}
+
+func (m *PortForwardMetrics) connectionOpened() {
+	if m != nil {
+		m.activeConnections.Add(1)
+		m.totalConnections.Add(1)
+	}
+}
+
+func (m *PortForwardMetrics) connectionClosed(err error) {
+	if m == nil {
+		return
+	}
+
+	m.activeConnections.Add(-1)
+	var e *websocket.CloseError
+	if err != nil && (!errors.As(err, &e) || e.Code != websocket.CloseNormalClosure) {
+		m.failedConnections.Add(1)
+	}
+}
+
+// countingWriter adds the number of bytes written to Out to counter.
+type countingWriter struct {
+	Out     io.Writer
+	Counter *atomic.Int64
+}
+
+func (w countingWriter) Write(p []byte) (int, error) {
+	count, err := w.Out.Write(p)
+	if w.Counter != nil {
+		w.Counter.Add(int64(count))
+	}
+	return count, err
 }
 
 type WebsocketPortForward struct {
@@ -83,8 +157,8 @@ func mkWebsocketConn(req *PortForwardRequest) (*WebsocketPortForward, error) {
 	return &ws, nil
 }
 
-func ExecPortForward(req *PortForwardRequest) {
-	listen, error := net.Listen("tcp", fmt.Sprintf("localhost:%d", req.LocalPort))
+func ExecPortForward(req *PortForwardRequest, metrics *PortForwardMetrics) {
+	listen, error := ListenPortForward(req)
 
 	// Handles eventual errors
 	if error != nil {
@@ -92,6 +166,20 @@ func ExecPortForward(req *PortForwardRequest) {
 		return
 	}
 
+	ServePortForward(listen, req, metrics)
+}
+
+// ListenPortForward listens on the local port of req, so that an unavailable port is reported to the caller.
+func ListenPortForward(req *PortForwardRequest) (net.Listener, error) {
+	listenAddress := req.ListenAddress
+	if listenAddress == "" {
+		listenAddress = "localhost"
+	}
+	return net.Listen("tcp", net.JoinHostPort(listenAddress, strconv.Itoa(int(req.LocalPort))))
+}
+
+// ServePortForward forwards the connections accepted by listen to the pod targeted by req, until listen is closed.
+func ServePortForward(listen net.Listener, req *PortForwardRequest, metrics *PortForwardMetrics) {
 	fmt.Printf("Listening on %s => %d\n", listen.Addr().String(), req.Port)
 
 	for {
@@ -99,19 +187,54 @@ func ExecPortForward(req *PortForwardRequest) {
 		con, error := listen.Accept()
 
 		// Handles eventual errors
+		if errors.Is(error, net.ErrClosed) {
+			return
+		}
 		if error != nil {
 			fmt.Println(error)
 			continue
 		}
 
-		go handleConnection(con, req)
+		go ForwardConnection(con, req, metrics)
+	}
+}
+
+// CheckPortForward checks that a pod of the service exposes the port and that the forward can be set up,
+// so that problems are reported before the first client connects.
+func CheckPortForward(req *PortForwardRequest) error {
+	pods, err := ExecListPods(req)
+	if err != nil {
+		return fmt.Errorf("cannot list the pods of the service: %w", err)
+	}
+
+	podFound := false
+	for _, pod := range pods.Pods {
+		if req.PodName != "" && pod.Name != req.PodName {
+			continue
+		}
+		podFound = true
+		if len(pod.Ports) > 0 && !slices.Contains(pod.Ports, req.Port) {
+			return fmt.Errorf("pod %s does not expose port %d, exposed ports: %v", pod.Name, req.Port, pod.Ports)
+		}
+	}
+	if !podFound {
+		return errors.New("no running pod found for the service")
+	}
+
+	wsConn, err := mkWebsocketConn(req)
+	if err != nil {
+		return fmt.Errorf("pod is unreachable: %w", err)
 	}
+	return wsConn.ws.Close()
 }
 
-func handleConnection(con net.Conn, req *PortForwardRequest) {
+// ForwardConnection forwards con to the port of the pod targeted by req until one of the sides closes the connection.
+func ForwardConnection(con net.Conn, req *PortForwardRequest, metrics *PortForwardMetrics) {
 	var errRet error
 	fmt.Printf("Connection accepted from %s => %d\n", con.RemoteAddr().String(), req.Port)
+	metrics.connectionOpened()
 	defer func() {
+		metrics.connectionClosed(errRet)
 		if err := con.Close(); err != nil {
 			log.Error("error closing connection: ", err)
 		}
@@ -130,7 +253,7 @@ func handleConnection(con net.Conn, req *PortForwardRequest) {
 		}
 	}()
 
-	wsConn, err := mkWebsocketConn(req)
+	wsConn, err := dialPortForward(req)
 	if err != nil {
 		errRet = err
 		log.Errorf("error while creating websocket connection: %v", err)
@@ -142,9 +265,56 @@ func handleConnection(con net.Conn, req *PortForwardRequest) {
 		}
 	}()
 
+	var sent, received *atomic.Int64
+	if metrics != nil {
+		sent, received = &metrics.bytesSent, &metrics.bytesReceived
+	}
 	go func() {
-		_, _ = io.Copy(wsConn, con)
+		_, _ = io.Copy(countingWriter{Out: wsConn, Counter: sent}, con)
 	}()
-	_, err = io.Copy(con, wsConn)
+	_, err = io.Copy(countingWriter{Out: con, Counter: received}, wsConn)
 	errRet = err
 }
+
+// dialPortForward creates the websocket of a connection, retrying when the agent is temporarily unavailable.
+func dialPortForward(req *PortForwardRequest) (*WebsocketPortForward, error) {
+	var err error
+	for attempt := 1; attempt <= PortForwardDialAttempts; attempt++ {
+		var wsConn *WebsocketPortForward
+		wsConn, err = mkWebsocketConn(req)
+		if err == nil {
+			return wsConn, nil
+		}
+
+		if attempt < PortForwardDialAttempts {
+			log.Warnf("error while creating websocket connection, retrying (%d/%d): %v", attempt, PortForwardDialAttempts, err)
+			time.Sleep(time.Duration(attempt) * time.Second)
+		}
+	}
+
+	return nil, err
+}
+
+// ForwardStdio forwards in and out to the port of the pod targeted by req until one of the sides closes the connection.
+// Nothing else is written to out, so it can be used as the ProxyCommand of ssh.
+func ForwardStdio(req *PortForwardRequest, in io.Reader, out io.Writer) error {
+	wsConn, err := dialPortForward(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = wsConn.ws.Close()
+	}()
+
+	go func() {
+		_, _ = io.Copy(wsConn, in)
+		_ = wsConn.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	}()
+	_, err = io.Copy(out, wsConn)
+
+	var e *websocket.CloseError
+	if errors.As(err, &e) && e.Code == websocket.CloseNormalClosure {
+		return nil
+	}
+	return err
+}
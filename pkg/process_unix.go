@@ -0,0 +1,22 @@
+//go:build !windows
+
+package pkg
+
+import (
+	"os"
+	"syscall"
+)
+
+// DetachedProcessAttributes starts a process in a new session, so it is not killed with the terminal.
+func DetachedProcessAttributes() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
+
+// IsProcessRunning returns true if a process with this pid exists.
+func IsProcessRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
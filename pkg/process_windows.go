@@ -0,0 +1,38 @@
+//go:build windows
+
+package pkg
+
+import (
+	"syscall"
+)
+
+// detachedProcess is the DETACHED_PROCESS creation flag: the process does not inherit the console.
+const detachedProcess = 0x00000008
+
+// DetachedProcessAttributes starts a process without console, so it is not killed with the terminal.
+func DetachedProcessAttributes() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: detachedProcess | syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// processQueryLimitedInformation is the PROCESS_QUERY_LIMITED_INFORMATION access right.
+const processQueryLimitedInformation = 0x1000
+
+// stillActive is the exit code of a process which has not exited yet.
+const stillActive = 259
+
+// IsProcessRunning returns true if a process with this pid exists and has not exited.
+func IsProcessRunning(pid int) bool {
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = syscall.CloseHandle(handle)
+	}()
+
+	var exitCode uint32
+	if err := syscall.GetExitCodeProcess(handle, &exitCode); err != nil {
+		return false
+	}
+	return exitCode == stillActive
+}
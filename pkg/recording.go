@@ -0,0 +1,244 @@
+package pkg
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// SessionRecorder writes a shell session to a file in the asciinema v2 format (asciicast),
+// see https://docs.asciinema.org/manual/asciicast/v2/
+// All methods are no-op on a nil recorder so it can be passed around when recording is disabled.
+type SessionRecorder struct {
+	mutex  sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+	start  time.Time
+	title  string
+	// bytes of a character split between two messages, by event type
+	pending map[string][]byte
+}
+
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     uint16            `json:"width"`
+	Height    uint16            `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// DefaultRecordingWidth and DefaultRecordingHeight are the terminal size recorded when stdin is not a terminal.
+const DefaultRecordingWidth = 80
+const DefaultRecordingHeight = 24
+
+// NewSessionRecorder creates the recording file. The header is written once the terminal size is known, by Start.
+func NewSessionRecorder(path string, title string) (*SessionRecorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionRecorder{file: file, writer: bufio.NewWriter(file), title: title, pending: make(map[string][]byte)}, nil
+}
+
+// Start writes the asciicast header. Event times are relative to the call to Start.
+func (r *SessionRecorder) Start(width uint16, height uint16) error {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.start = time.Now()
+	header, err := json.Marshal(asciicastHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: r.start.Unix(),
+		Title:     r.title,
+		Env:       map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.writer.Write(append(header, '\n'))
+	return err
+}
+
+// RecordOutput records data received from the container.
+func (r *SessionRecorder) RecordOutput(data []byte) {
+	r.recordEvent("o", data)
+}
+
+// RecordInput records data typed by the user.
+func (r *SessionRecorder) RecordInput(data []byte) {
+	r.recordEvent("i", data)
+}
+
+// RecordResize records a change of the terminal size.
+func (r *SessionRecorder) RecordResize(width uint16, height uint16) {
+	r.recordEvent("r", []byte(fmt.Sprintf("%dx%d", width, height)))
+}
+
+func (r *SessionRecorder) recordEvent(eventType string, bytes []byte) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// asciicast events are UTF-8 strings, keep an incomplete character for the next event
+	bytes = append(r.pending[eventType], bytes...)
+	data, rest := splitIncompleteRune(bytes)
+	r.pending[eventType] = rest
+	if len(data) == 0 {
+		return
+	}
+
+	event, err := json.Marshal([]interface{}{time.Since(r.start).Seconds(), eventType, string(data)})
+	if err != nil {
+		return
+	}
+	_, _ = r.writer.Write(append(event, '\n'))
+}
+
+// splitIncompleteRune splits data before the last character if this one is incomplete.
+func splitIncompleteRune(data []byte) ([]byte, []byte) {
+	// an UTF-8 character is at most 4 bytes long
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if utf8.FullRune(data[i:]) {
+				return data, nil
+			}
+			return data[:i], data[i:]
+		}
+	}
+	return data, nil
+}
+
+// Close flushes the recording to disk.
+func (r *SessionRecorder) Close() error {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.writer.Flush(); err != nil {
+		_ = r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+// recordingWriter records everything written to Out as output events.
+type recordingWriter struct {
+	Out      io.Writer
+	Recorder *SessionRecorder
+}
+
+func (w recordingWriter) Write(p []byte) (int, error) {
+	w.Recorder.RecordOutput(p)
+	return w.Out.Write(p)
+}
+
+// recordingReader records everything read from In as input events.
+type recordingReader struct {
+	In       io.Reader
+	Recorder *SessionRecorder
+}
+
+func (r recordingReader) Read(p []byte) (int, error) {
+	count, err := r.In.Read(p)
+	if count > 0 {
+		r.Recorder.RecordInput(p[:count])
+	}
+	return count, err
+}
+
+// ReplaySession plays an asciicast recording back to out. speed multiplies the playback speed and,
+// when maxIdle is not zero, pauses between two outputs are shortened to maxIdle.
+func ReplaySession(in io.Reader, out io.Writer, speed float64, maxIdle time.Duration) error {
+	if speed <= 0 {
+		return errors.New("speed must be greater than 0")
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return errors.New("empty recording")
+	}
+	var header asciicastHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return fmt.Errorf("invalid recording header: %w", err)
+	}
+	if header.Version != 2 {
+		return fmt.Errorf("unsupported asciicast version %d", header.Version)
+	}
+
+	previous := 0.0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		eventTime, eventType, data, err := parseAsciicastEvent(line)
+		if err != nil {
+			return err
+		}
+		if eventType != "o" {
+			continue
+		}
+
+		delay := time.Duration((eventTime - previous) / speed * float64(time.Second))
+		if maxIdle > 0 && delay > maxIdle {
+			delay = maxIdle
+		}
+		previous = eventTime
+		time.Sleep(delay)
+
+		if _, err := io.WriteString(out, data); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func parseAsciicastEvent(line string) (float64, string, string, error) {
+	var event []json.RawMessage
+	if err := json.Unmarshal([]byte(line), &event); err != nil || len(event) != 3 {
+		return 0, "", "", fmt.Errorf("invalid recording event: %s", line)
+	}
+
+	var eventTime float64
+	var eventType, data string
+	if err := json.Unmarshal(event[0], &eventTime); err != nil {
+		return 0, "", "", fmt.Errorf("invalid recording event time: %s", line)
+	}
+	if err := json.Unmarshal(event[1], &eventType); err != nil {
+		return 0, "", "", fmt.Errorf("invalid recording event type: %s", line)
+	}
+	if err := json.Unmarshal(event[2], &data); err != nil {
+		return 0, "", "", fmt.Errorf("invalid recording event data: %s", line)
+	}
+
+	return eventTime, eventType, data, nil
+}
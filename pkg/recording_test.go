@@ -0,0 +1,67 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionRecorderReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.cast")
+
+	recorder, err := NewSessionRecorder(path, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Start(80, 24); err != nil {
+		t.Fatal(err)
+	}
+	recorder.RecordInput([]byte("ls\r"))
+	// "é" split between two messages
+	recorder.RecordOutput([]byte("caf\xc3"))
+	recorder.RecordOutput([]byte("\xa9\r\n"))
+	recorder.RecordResize(120, 40)
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected a header and 4 events, got %q", content)
+	}
+	if !strings.Contains(lines[0], `"version":2`) || !strings.Contains(lines[0], `"width":80`) {
+		t.Fatalf("unexpected header %s", lines[0])
+	}
+	if !strings.Contains(lines[4], `"r","120x40"`) {
+		t.Fatalf("unexpected resize event %s", lines[4])
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var out strings.Builder
+	if err := ReplaySession(file, &out, 1, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "café\r\n" {
+		t.Fatalf("unexpected replay output %q", out.String())
+	}
+}
+
+func TestReplaySessionInvalidHeader(t *testing.T) {
+	var out strings.Builder
+	if err := ReplaySession(strings.NewReader(`{"version":1}`+"\n"), &out, 1, 0); err == nil {
+		t.Fatal("expected an error for an asciicast v1 file")
+	}
+}
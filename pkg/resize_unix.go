@@ -0,0 +1,14 @@
+//go:build !windows
+
+package pkg
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyTerminalResize relays the changes of the terminal size to c.
+func notifyTerminalResize(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGWINCH)
+}
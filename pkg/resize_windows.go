@@ -0,0 +1,10 @@
+//go:build windows
+
+package pkg
+
+import (
+	"os"
+)
+
+// notifyTerminalResize is a no-op: there is no resize signal on Windows.
+func notifyTerminalResize(c chan<- os.Signal) {}
@@ -0,0 +1,229 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/qovery/qovery-cli/utils"
+)
+
+// Kinds of secret manager a cluster can access
+const (
+	SecretManagerAwsSecretsManager = "AWS_SECRETS_MANAGER"
+	SecretManagerAwsParameterStore = "AWS_PARAMETER_STORE"
+	SecretManagerGcpSecretManager  = "GCP_SECRET_MANAGER"
+)
+
+// secretManagerAccessesField is the field of the cluster holding its secret manager accesses
+const secretManagerAccessesField = "secret_manager_accesses"
+
+// clusterReadOnlyFields are the fields of a cluster returned by the API which are not part of a cluster edit request
+var clusterReadOnlyFields = []string{
+	"id", "created_at", "updated_at", "organization", "status", "deployment_status", "has_access", "is_default", "is_demo",
+	"estimated_cloud_provider_cost", "version",
+}
+
+// SecretManagerAccessRequest is the configuration of a secret manager access to add to a cluster.
+type SecretManagerAccessRequest struct {
+	Name string
+	Kind string
+	// AWS
+	Region          string
+	RoleArn         string
+	AccessKeyId     string
+	SecretAccessKey string
+	// GCP
+	ProjectId       string
+	JsonCredentials string
+}
+
+// SecretManagerAccess is a secret manager access of a cluster, as returned by the API.
+type SecretManagerAccess struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// ToJson validates the configuration and returns the secret manager access as sent to the API.
+func (request SecretManagerAccessRequest) ToJson() (map[string]interface{}, error) {
+	if strings.TrimSpace(request.Name) == "" {
+		return nil, errors.New("the name of the secret manager access is required")
+	}
+
+	config := make(map[string]interface{})
+	switch request.Kind {
+	case SecretManagerAwsSecretsManager, SecretManagerAwsParameterStore:
+		if request.Region == "" {
+			return nil, errors.New("--region is required for AWS secret managers")
+		}
+		config["region"] = request.Region
+		switch {
+		case request.RoleArn != "" && request.AccessKeyId == "" && request.SecretAccessKey == "":
+			config["role_arn"] = request.RoleArn
+		case request.RoleArn == "" && request.AccessKeyId != "" && request.SecretAccessKey != "":
+			config["access_key_id"] = request.AccessKeyId
+			config["secret_access_key"] = request.SecretAccessKey
+		default:
+			return nil, errors.New("either --role-arn, or --access-key-id and --secret-access-key are required for AWS secret managers")
+		}
+	case SecretManagerGcpSecretManager:
+		if request.ProjectId == "" || request.JsonCredentials == "" {
+			return nil, errors.New("--project-id and --credentials-file are required for GCP Secret Manager")
+		}
+		if !json.Valid([]byte(request.JsonCredentials)) {
+			return nil, errors.New("the GCP credentials must be a JSON service account key")
+		}
+		config["project_id"] = request.ProjectId
+		config["json_credentials"] = request.JsonCredentials
+	default:
+		return nil, fmt.Errorf("unknown secret manager kind %s", request.Kind)
+	}
+
+	return map[string]interface{}{
+		"name":   request.Name,
+		"kind":   request.Kind,
+		"config": config,
+	}, nil
+}
+
+// GetSecretManagerAccesses returns the secret manager accesses of the cluster document.
+func GetSecretManagerAccesses(cluster map[string]interface{}) ([]SecretManagerAccess, error) {
+	content, err := json.Marshal(cluster[secretManagerAccessesField])
+	if err != nil {
+		return nil, err
+	}
+
+	var accesses []SecretManagerAccess
+	if err := json.Unmarshal(content, &accesses); err != nil {
+		return nil, err
+	}
+	return accesses, nil
+}
+
+// AddSecretManagerAccess adds access to the cluster document, its name must not be used by another access.
+func AddSecretManagerAccess(cluster map[string]interface{}, access map[string]interface{}) error {
+	accesses, _ := cluster[secretManagerAccessesField].([]interface{})
+	for _, existing := range accesses {
+		if existing, ok := existing.(map[string]interface{}); ok && existing["name"] == access["name"] {
+			return fmt.Errorf("secret manager access %s already exists on the cluster", access["name"])
+		}
+	}
+
+	cluster[secretManagerAccessesField] = append(accesses, access)
+	return nil
+}
+
+// RemoveSecretManagerAccess removes the access named name from the cluster document.
+func RemoveSecretManagerAccess(cluster map[string]interface{}, name string) error {
+	accesses, _ := cluster[secretManagerAccessesField].([]interface{})
+	kept := make([]interface{}, 0, len(accesses))
+	for _, existing := range accesses {
+		if existing, ok := existing.(map[string]interface{}); ok && existing["name"] == name {
+			continue
+		}
+		kept = append(kept, existing)
+	}
+
+	if len(kept) == len(accesses) {
+		return fmt.Errorf("secret manager access %s not found on the cluster", name)
+	}
+
+	cluster[secretManagerAccessesField] = kept
+	return nil
+}
+
+// GetClusterDocument returns the cluster as a JSON document, so it can be edited and sent back without losing the fields
+// the CLI does not know about.
+func GetClusterDocument(organizationId string, clusterId string) (map[string]interface{}, error) {
+	body, err := clusterDocumentRequest(http.MethodGet, organizationId, clusterId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := make(map[string]interface{})
+	if err := json.Unmarshal(body, &cluster); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// EditClusterDocument replaces the cluster by the cluster document, sent as a cluster edit request.
+func EditClusterDocument(organizationId string, clusterId string, cluster map[string]interface{}) error {
+	request, err := clusterEditRequest(cluster)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = clusterDocumentRequest(http.MethodPut, organizationId, clusterId, bytes.NewReader(content))
+	return err
+}
+
+// clusterEditRequest returns the edit request of the cluster document, without its read-only fields.
+// The API does not return the credentials of the secret manager accesses: sending back an access without its configuration
+// would drop them, so the request is refused.
+func clusterEditRequest(cluster map[string]interface{}) (map[string]interface{}, error) {
+	request := make(map[string]interface{}, len(cluster))
+	for field, value := range cluster {
+		request[field] = value
+	}
+	for _, field := range clusterReadOnlyFields {
+		delete(request, field)
+	}
+
+	accesses, _ := request[secretManagerAccessesField].([]interface{})
+	for _, access := range accesses {
+		access, ok := access.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid secret manager access %v", access)
+		}
+		if _, ok := access["config"]; !ok {
+			return nil, fmt.Errorf("the credentials of the secret manager access %s are not returned by the API and would be lost by editing the cluster: "+
+				"the CLI only adds the first access of a cluster and removes its last one, manage the accesses of this cluster from the Qovery console", access["name"])
+		}
+	}
+
+	return request, nil
+}
+
+func clusterDocumentRequest(method string, organizationId string, clusterId string, body io.Reader) ([]byte, error) {
+	tokenType, token, err := utils.GetAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/organization/%s/cluster/%s", utils.GetAPIBaseURL(), organizationId, clusterId)
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", utils.GetAuthorizationHeaderValue(tokenType, token))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("received %s response for cluster %s: %s", res.Status, clusterId, string(content))
+	}
+
+	return content, nil
+}
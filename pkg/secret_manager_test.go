@@ -0,0 +1,218 @@
+package pkg
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestSecretManagerAccessRequestToJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		request SecretManagerAccessRequest
+		config  map[string]interface{}
+		err     bool
+	}{
+		{
+			name:    "aws role",
+			request: SecretManagerAccessRequest{Name: "aws", Kind: SecretManagerAwsSecretsManager, Region: "eu-west-3", RoleArn: "arn:aws:iam::123:role/secrets"},
+			config:  map[string]interface{}{"region": "eu-west-3", "role_arn": "arn:aws:iam::123:role/secrets"},
+		},
+		{
+			name:    "aws access keys",
+			request: SecretManagerAccessRequest{Name: "ssm", Kind: SecretManagerAwsParameterStore, Region: "us-east-1", AccessKeyId: "AKIA", SecretAccessKey: "secret"},
+			config:  map[string]interface{}{"region": "us-east-1", "access_key_id": "AKIA", "secret_access_key": "secret"},
+		},
+		{
+			name:    "aws role and access keys",
+			request: SecretManagerAccessRequest{Name: "aws", Kind: SecretManagerAwsSecretsManager, Region: "eu-west-3", RoleArn: "arn", AccessKeyId: "AKIA", SecretAccessKey: "secret"},
+			err:     true,
+		},
+		{
+			name:    "aws without region",
+			request: SecretManagerAccessRequest{Name: "aws", Kind: SecretManagerAwsSecretsManager, RoleArn: "arn"},
+			err:     true,
+		},
+		{
+			name:    "gcp",
+			request: SecretManagerAccessRequest{Name: "gcp", Kind: SecretManagerGcpSecretManager, ProjectId: "my-project", JsonCredentials: `{"type":"service_account"}`},
+			config:  map[string]interface{}{"project_id": "my-project", "json_credentials": `{"type":"service_account"}`},
+		},
+		{
+			name:    "gcp invalid credentials",
+			request: SecretManagerAccessRequest{Name: "gcp", Kind: SecretManagerGcpSecretManager, ProjectId: "my-project", JsonCredentials: "not json"},
+			err:     true,
+		},
+		{
+			name:    "unknown kind",
+			request: SecretManagerAccessRequest{Name: "vault", Kind: "VAULT"},
+			err:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			access, err := tt.request.ToJson()
+			if tt.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(access["config"], tt.config) {
+				t.Fatalf("expected %v, got %v", tt.config, access["config"])
+			}
+		})
+	}
+}
+
+func TestAddAndRemoveSecretManagerAccess(t *testing.T) {
+	cluster := map[string]interface{}{
+		"name": "production",
+		"secret_manager_accesses": []interface{}{
+			map[string]interface{}{"id": "1", "name": "aws", "kind": SecretManagerAwsSecretsManager},
+		},
+	}
+
+	if err := AddSecretManagerAccess(cluster, map[string]interface{}{"name": "aws"}); err == nil {
+		t.Fatal("expected an error when adding an existing access")
+	}
+	if err := AddSecretManagerAccess(cluster, map[string]interface{}{"name": "gcp", "kind": SecretManagerGcpSecretManager}); err != nil {
+		t.Fatal(err)
+	}
+
+	accesses, err := GetSecretManagerAccesses(cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []SecretManagerAccess{{Id: "1", Name: "aws", Kind: SecretManagerAwsSecretsManager}, {Name: "gcp", Kind: SecretManagerGcpSecretManager}}
+	if !reflect.DeepEqual(accesses, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, accesses)
+	}
+
+	if err := RemoveSecretManagerAccess(cluster, "aws"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveSecretManagerAccess(cluster, "aws"); err == nil {
+		t.Fatal("expected an error when removing a missing access")
+	}
+	if cluster["name"] != "production" {
+		t.Fatal("expected the other fields of the cluster to be kept")
+	}
+}
+
+// clusterResponse is a cluster as returned by GET /organization/{organizationId}/cluster/{clusterId}
+const clusterResponse = `{
+	"id": "cluster-id",
+	"created_at": "2026-01-12T09:30:00.000Z",
+	"updated_at": "2026-03-02T16:45:00.000Z",
+	"organization": {"id": "organization-id"},
+	"name": "production",
+	"description": "",
+	"region": "eu-west-3",
+	"cloud_provider": "AWS",
+	"kubernetes": "MANAGED",
+	"production": true,
+	"min_running_nodes": 3,
+	"max_running_nodes": 10,
+	"instance_type": "T3A_LARGE",
+	"disk_size": 50,
+	"status": "DEPLOYED",
+	"has_access": true,
+	"is_default": false,
+	"version": "1.31",
+	"secret_manager_accesses": []
+}`
+
+// clusterEditRequestBody is the cluster edit request expected after adding a secret manager access to clusterResponse
+const clusterEditRequestBody = `{
+	"name": "production",
+	"description": "",
+	"region": "eu-west-3",
+	"cloud_provider": "AWS",
+	"kubernetes": "MANAGED",
+	"production": true,
+	"min_running_nodes": 3,
+	"max_running_nodes": 10,
+	"instance_type": "T3A_LARGE",
+	"disk_size": 50,
+	"secret_manager_accesses": [
+		{"name": "aws", "kind": "AWS_SECRETS_MANAGER", "config": {"region": "eu-west-3", "role_arn": "arn:aws:iam::123:role/secrets"}}
+	]
+}`
+
+func TestEditClusterDocument(t *testing.T) {
+	t.Setenv("QOVERY_CLI_ACCESS_TOKEN", "fake-token")
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	url := "https://api.qovery.com/organization/organization-id/cluster/cluster-id"
+	httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, clusterResponse))
+
+	var requestBody []byte
+	httpmock.RegisterResponder("PUT", url, func(req *http.Request) (*http.Response, error) {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		return httpmock.NewStringResponse(200, clusterResponse), nil
+	})
+
+	cluster, err := GetClusterDocument("organization-id", "cluster-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	access, err := SecretManagerAccessRequest{Name: "aws", Kind: SecretManagerAwsSecretsManager, Region: "eu-west-3", RoleArn: "arn:aws:iam::123:role/secrets"}.ToJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AddSecretManagerAccess(cluster, access); err != nil {
+		t.Fatal(err)
+	}
+	if err := EditClusterDocument("organization-id", "cluster-id", cluster); err != nil {
+		t.Fatal(err)
+	}
+
+	var got, expected map[string]interface{}
+	if err := json.Unmarshal(requestBody, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(clusterEditRequestBody), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestEditClusterDocumentKeepsCredentials(t *testing.T) {
+	cluster := map[string]interface{}{
+		"name": "production",
+		// the API does not return the configuration of the accesses, which holds their credentials
+		"secret_manager_accesses": []interface{}{
+			map[string]interface{}{"id": "1", "name": "aws", "kind": SecretManagerAwsSecretsManager},
+		},
+	}
+	if err := AddSecretManagerAccess(cluster, map[string]interface{}{"name": "gcp", "kind": SecretManagerGcpSecretManager, "config": map[string]interface{}{}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := clusterEditRequest(cluster); err == nil {
+		t.Fatal("expected an error when an access would lose its credentials")
+	}
+
+	if err := RemoveSecretManagerAccess(cluster, "aws"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clusterEditRequest(cluster); err != nil {
+		t.Fatal(err)
+	}
+}
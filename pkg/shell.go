@@ -56,7 +56,8 @@ func (s *ShellRequest) SetTtySize(width uint16, height uint16) {
 	s.TtyHeight = height
 }
 
-func ExecShell(req TerminalSize, path string) {
+// ExecShell opens an interactive shell session. When recorder is not nil, the session is recorded to it.
+func ExecShell(req TerminalSize, path string, recorder *SessionRecorder) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -101,6 +102,35 @@ func ExecShell(req TerminalSize, path string) {
 
 		stdinReader = currentConsole
 		stdoutWriter = currentConsole
+
+		if recorder != nil {
+			if err := recorder.Start(winSize.Width, winSize.Height); err != nil {
+				log.Fatal("error while starting the session recording", err)
+			}
+
+			resizeChan := make(chan os.Signal, 1)
+			notifyTerminalResize(resizeChan)
+			defer signal.Stop(resizeChan)
+			go func() {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-resizeChan:
+						if size, err := currentConsole.Size(); err == nil {
+							recorder.RecordResize(size.Width, size.Height)
+						}
+					}
+				}
+			}()
+		}
+	} else if err := recorder.Start(DefaultRecordingWidth, DefaultRecordingHeight); err != nil {
+		log.Fatal("error while starting the session recording", err)
+	}
+
+	if recorder != nil {
+		stdinReader = recordingReader{In: stdinReader, Recorder: recorder}
+		stdoutWriter = recordingWriter{Out: stdoutWriter, Recorder: recorder}
 	}
 
 	stdIn := make(chan []byte)
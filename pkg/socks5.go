@@ -0,0 +1,141 @@
+package pkg
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SOCKS5 protocol constants, see RFC 1928
+const (
+	socks5Version           = 0x05
+	socks5NoAuthentication  = 0x00
+	socks5NoAcceptableAuth  = 0xff
+	socks5CommandConnect    = 0x01
+	socks5AddressIPv4       = 0x01
+	socks5AddressDomain     = 0x03
+	socks5AddressIPv6       = 0x04
+	socks5ReplySucceeded    = 0x00
+	socks5ReplyNotAllowed   = 0x02
+	socks5ReplyNotSupported = 0x07
+)
+
+// Socks5Resolver returns the port-forward to use to reach host:port, or false if the destination is unknown.
+type Socks5Resolver func(host string, port uint16) (*PortForwardRequest, bool)
+
+// ServeSocks5 runs a SOCKS5 proxy on listener, forwarding the CONNECT requests resolved by resolve through port-forwards.
+// Only the CONNECT command without authentication is supported, which is what browsers and most clients use.
+func ServeSocks5(listener net.Listener, resolve Socks5Resolver, metrics *PortForwardMetrics) error {
+	for {
+		con, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			req, err := socks5Handshake(con, resolve)
+			if err != nil {
+				log.Warnf("socks5 connection from %s rejected: %v", con.RemoteAddr().String(), err)
+				_ = con.Close()
+				return
+			}
+
+			ForwardConnection(con, req, metrics)
+		}()
+	}
+}
+
+// socks5Handshake negotiates the connection and reads the destination of the client.
+func socks5Handshake(con io.ReadWriter, resolve Socks5Resolver) (*PortForwardRequest, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(con, header); err != nil {
+		return nil, err
+	}
+	if header[0] != socks5Version {
+		return nil, fmt.Errorf("unsupported socks version %d", header[0])
+	}
+
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(con, methods); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(methods, socks5NoAuthentication) {
+		_, _ = con.Write([]byte{socks5Version, socks5NoAcceptableAuth})
+		return nil, errors.New("client requires authentication")
+	}
+	if _, err := con.Write([]byte{socks5Version, socks5NoAuthentication}); err != nil {
+		return nil, err
+	}
+
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(con, request); err != nil {
+		return nil, err
+	}
+	if request[1] != socks5CommandConnect {
+		_ = writeSocks5Reply(con, socks5ReplyNotSupported)
+		return nil, fmt.Errorf("unsupported socks command %d", request[1])
+	}
+
+	host, err := readSocks5Address(con, request[3])
+	if err != nil {
+		_ = writeSocks5Reply(con, socks5ReplyNotSupported)
+		return nil, err
+	}
+
+	portBytes := make([]byte, 2)
+	if _, err := io.ReadFull(con, portBytes); err != nil {
+		return nil, err
+	}
+	port := binary.BigEndian.Uint16(portBytes)
+
+	req, ok := resolve(host, port)
+	if !ok {
+		_ = writeSocks5Reply(con, socks5ReplyNotAllowed)
+		return nil, fmt.Errorf("unknown destination %s", net.JoinHostPort(host, strconv.Itoa(int(port))))
+	}
+
+	if err := writeSocks5Reply(con, socks5ReplySucceeded); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func readSocks5Address(con io.Reader, addressType byte) (string, error) {
+	switch addressType {
+	case socks5AddressIPv4, socks5AddressIPv6:
+		size := net.IPv4len
+		if addressType == socks5AddressIPv6 {
+			size = net.IPv6len
+		}
+		ip := make([]byte, size)
+		if _, err := io.ReadFull(con, ip); err != nil {
+			return "", err
+		}
+		return net.IP(ip).String(), nil
+	case socks5AddressDomain:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(con, length); err != nil {
+			return "", err
+		}
+		domain := make([]byte, length[0])
+		if _, err := io.ReadFull(con, domain); err != nil {
+			return "", err
+		}
+		return string(domain), nil
+	default:
+		return "", fmt.Errorf("unsupported socks address type %d", addressType)
+	}
+}
+
+// writeSocks5Reply answers the CONNECT request. The bound address is not meaningful for a port-forward so it is zeroed.
+func writeSocks5Reply(con io.Writer, reply byte) error {
+	_, err := con.Write([]byte{socks5Version, reply, 0x00, socks5AddressIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+type socks5TestConn struct {
+	in  io.Reader
+	out bytes.Buffer
+}
+
+func (c *socks5TestConn) Read(p []byte) (int, error) {
+	return c.in.Read(p)
+}
+
+func (c *socks5TestConn) Write(p []byte) (int, error) {
+	return c.out.Write(p)
+}
+
+func TestSocks5Handshake(t *testing.T) {
+	resolve := func(host string, port uint16) (*PortForwardRequest, bool) {
+		if host != "postgresql-z1a2b3c4d" || port != 5432 {
+			return nil, false
+		}
+		return &PortForwardRequest{Port: port}, true
+	}
+
+	domain := "postgresql-z1a2b3c4d"
+	request := []byte{socks5Version, 1, socks5NoAuthentication, socks5Version, socks5CommandConnect, 0, socks5AddressDomain, byte(len(domain))}
+	request = append(request, domain...)
+	request = append(request, 0x15, 0x38)
+
+	con := &socks5TestConn{in: bytes.NewReader(request)}
+	req, err := socks5Handshake(con, resolve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Port != 5432 {
+		t.Fatalf("expected port 5432, got %d", req.Port)
+	}
+	if reply := con.out.Bytes(); len(reply) != 12 || reply[3] != socks5ReplySucceeded {
+		t.Fatalf("unexpected reply %v", reply)
+	}
+
+	request[len(request)-1] = 0x39
+	con = &socks5TestConn{in: bytes.NewReader(request)}
+	if _, err := socks5Handshake(con, resolve); err == nil {
+		t.Fatal("expected an error for an unknown destination")
+	}
+}
@@ -114,6 +114,8 @@ type EnvVarLineOutput struct {
 	IsSecret          bool
 	AliasParentKey    *string
 	OverrideParentKey *string
+	// SecretManagerAccessId is set for external secrets, whose value is a reference in the secret manager
+	SecretManagerAccessId *string
 }
 
 func (e EnvVarLineOutput) Data(showValues bool) []string {
@@ -162,6 +164,11 @@ func FromEnvironmentVariableToEnvVarLineOutput(envVar qovery.VariableResponse) E
 		value = envVar.Value.Get()
 	}
 
+	var secretManagerAccessId *string
+	if id := envVar.GetSecretManagerAccessId(); id != "" {
+		secretManagerAccessId = &id
+	}
+
 	return EnvVarLineOutput{
 		Id:                envVar.Id,
 		Key:               envVar.Key,
@@ -173,6 +180,8 @@ func FromEnvironmentVariableToEnvVarLineOutput(envVar qovery.VariableResponse) E
 		IsSecret:          envVar.IsSecret,
 		AliasParentKey:    aliasParentKey,
 		OverrideParentKey: overrideParentKey,
+
+		SecretManagerAccessId: secretManagerAccessId,
 	}
 }
 
@@ -855,6 +864,55 @@ FirstLoop:
 	return &finalValue
 }
 
+// ScopePriority orders the scopes from the least to the most specific: a variable overrides the variables of lower priority.
+func ScopePriority(scope string) int {
+	switch qovery.APIVariableScopeEnum(scope) {
+	case qovery.APIVARIABLESCOPEENUM_BUILT_IN:
+		return 0
+	case qovery.APIVARIABLESCOPEENUM_PROJECT:
+		return 1
+	case qovery.APIVARIABLESCOPEENUM_ENVIRONMENT:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// EffectiveVariables keeps, for each key, the variable of the most specific scope: the one the service sees.
+// The variables are sorted by key.
+func EffectiveVariables(variables []EnvVarLineOutput) []EnvVarLineOutput {
+	effective := make(map[string]EnvVarLineOutput)
+	for _, variable := range variables {
+		if current, ok := effective[variable.Key]; ok && ScopePriority(current.Scope) > ScopePriority(variable.Scope) {
+			continue
+		}
+		effective[variable.Key] = variable
+	}
+
+	effectiveVariables := make([]EnvVarLineOutput, 0, len(effective))
+	for _, variable := range effective {
+		effectiveVariables = append(effectiveVariables, variable)
+	}
+	sort.Slice(effectiveVariables, func(i, j int) bool {
+		return effectiveVariables[i].Key < effectiveVariables[j].Key
+	})
+
+	return effectiveVariables
+}
+
+// ResolveVariables returns the variables as the service sees them, by key: overrides replace the variable they override,
+// aliases take the value of their parent and {{KEY}} references are interpolated. Secrets without value are nil.
+func ResolveVariables(variables []EnvVarLineOutput) map[string]*string {
+	effectiveVariables := EffectiveVariables(variables)
+
+	resolved := make(map[string]*string)
+	for _, variable := range effectiveVariables {
+		resolved[variable.Key] = getInterpolatedValue(variable.Value, effectiveVariables, variable.AliasParentKey)
+	}
+
+	return resolved
+}
+
 func getValueOrDefault(value *string) string {
 	if value == nil {
 		return "xxx secret xxx"
@@ -1617,6 +1617,61 @@ func GetServiceNameByIdAndType(client *qovery.APIClient, serviceId string, servi
 	}
 }
 
+// GetEnvironmentServiceNamesById returns the names of the services of an environment by id, with one call per service type.
+func GetEnvironmentServiceNamesById(client *qovery.APIClient, environmentId string) (map[string]string, error) {
+	names := make(map[string]string)
+
+	applications, _, err := client.ApplicationsAPI.ListApplication(context.Background(), environmentId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	for _, application := range applications.GetResults() {
+		names[application.Id] = application.Name
+	}
+
+	containers, _, err := client.ContainersAPI.ListContainer(context.Background(), environmentId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range containers.GetResults() {
+		names[container.Id] = container.Name
+	}
+
+	databases, _, err := client.DatabasesAPI.ListDatabase(context.Background(), environmentId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	for _, database := range databases.GetResults() {
+		names[database.Id] = database.Name
+	}
+
+	jobs, _, err := client.JobsAPI.ListJobs(context.Background(), environmentId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs.GetResults() {
+		names[GetJobId(&job)] = GetJobName(&job)
+	}
+
+	helms, _, err := client.HelmsAPI.ListHelms(context.Background(), environmentId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	for _, helm := range helms.GetResults() {
+		names[helm.Id] = helm.Name
+	}
+
+	terraforms, _, err := client.TerraformsAPI.ListTerraforms(context.Background(), environmentId).Execute()
+	if err != nil {
+		return nil, err
+	}
+	for _, terraform := range terraforms.GetResults() {
+		names[terraform.Id] = terraform.Name
+	}
+
+	return names, nil
+}
+
 func GetDeploymentStageId(client *qovery.APIClient, serviceId string) string {
 	sourceDeploymentStage, _, err := client.DeploymentStageMainCallsAPI.GetServiceDeploymentStage(context.Background(), serviceId).Execute()
 